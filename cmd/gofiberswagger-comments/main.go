@@ -0,0 +1,31 @@
+// gofiberswagger-comments writes the doc comments of a module into an index file, which can be loaded using
+// gofiberswagger.LoadDocComments when the source isn't available at runtime, eg.
+//
+//	//go:generate go run github.com/TDiblik/gofiber-swagger/cmd/gofiberswagger-comments -dir . -o ./generated/swagger/comments.json
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "module directory to parse")
+	output := flag.String("o", "./generated/swagger/comments.json", "output file")
+	flag.Parse()
+
+	comments, err := gofiberswagger.ParseDocComments(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(*output), 0o766); err != nil {
+		log.Fatal(err)
+	}
+	if err := comments.WriteFile(*output); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,94 @@
+// gofiberswagger-gen generates the schemas of the structs of a package ahead of time, so gofiberswagger doesn't have to walk them
+// using reflection at startup. The generated file registers them to the default generator (see gofiberswagger.RegisterGeneratedSchemas), eg.
+//
+//	//go:generate go run github.com/TDiblik/gofiber-swagger/cmd/gofiberswagger-gen -o gofiberswagger_schemas.go
+//
+// Structs which can't be documented statically (embedded structs, `swaggertype` / `xml` tags, `validate` tags other than required,
+// custom marshalers, enums, ...) are left out and keep getting documented using reflection.
+package main
+
+import (
+	"errors"
+	"flag"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var errPackagesFailed = errors.New("gofiberswagger-gen: unable to load the packages")
+
+func main() {
+	output := flag.String("o", "gofiberswagger_schemas.go", "name of the generated file, written into the directory of each package")
+	type_names := flag.String("types", "", "comma separated names of the structs to generate, every struct of the package by default")
+	verbose := flag.Bool("v", false, "log the structs which can't be generated")
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	only := map[string]bool{}
+	for _, name := range strings.Split(*type_names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			only[name] = true
+		}
+	}
+
+	pkgs, err := loadPackages(patterns, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		generated, skipped := generatePackage(pkg.Types, only)
+		if *verbose {
+			for _, reason := range skipped {
+				log.Println("gofiberswagger-gen:", pkg.PkgPath+"."+reason)
+			}
+		}
+
+		target := filepath.Join(pkg.Dir, *output)
+		if len(generated) == 0 {
+			log.Println("gofiberswagger-gen: no structs to generate in", pkg.PkgPath)
+			continue
+		}
+		source, err := renderPackage(pkg.Types, generated)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(target, source, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// loadPackages loads and type checks the packages. Errors coming from a previously generated file get ignored,
+// since it's getting regenerated (eg. it references a struct which got removed since).
+func loadPackages(patterns []string, output string) ([]*packages.Package, error) {
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+		Fset: token.NewFileSet(),
+	}
+	pkgs, err := packages.Load(config, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := false
+	for _, pkg := range pkgs {
+		for _, pkg_error := range pkg.Errors {
+			if filepath.Base(strings.Split(pkg_error.Pos, ":")[0]) == output {
+				continue
+			}
+			log.Println("gofiberswagger-gen:", pkg_error)
+			failed = true
+		}
+	}
+	if failed {
+		return nil, errPackagesFailed
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/TDiblik/gofiber-swagger/cmd/gofiberswagger-gen/testdata/models"
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const testOutput = "gofiberswagger_schemas.go"
+
+func TestGeneratedFileIsUpToDate(t *testing.T) {
+	pkgs, err := loadPackages([]string{"./testdata/models"}, testOutput)
+	assert.NoError(t, err)
+	assert.Len(t, pkgs, 1)
+
+	generated, skipped := generatePackage(pkgs[0].Types, nil)
+	assert.Len(t, generated, 3)
+	assert.Len(t, skipped, 4)
+
+	source, err := renderPackage(pkgs[0].Types, generated)
+	assert.NoError(t, err)
+	committed, err := os.ReadFile("./testdata/models/" + testOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, string(committed), string(source), "run `go run ../..` inside testdata/models")
+}
+
+// documentComponents registers a route per type and returns the components of the served document.
+func documentComponents(t *testing.T, generator *gofiberswagger.Generator, types ...reflect.Type) map[string]any {
+	app := fiber.New()
+	router := generator.NewRouter(app)
+	for _, schema_type := range types {
+		router.Post("/"+schema_type.Name(), &gofiberswagger.RouteInfo{
+			RequestBody: &gofiberswagger.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(generator.CreateSchema(schema_type))},
+			Responses:   gofiberswagger.NewResponsesRaw(map[string]*gofiberswagger.ResponseRef{"200": {Value: openapi3.NewResponse().WithDescription("OK")}}),
+		}, func(c fiber.Ctx) error { return nil })
+	}
+	assert.NoError(t, generator.Register(app))
+
+	response, err := app.Test(httptest.NewRequest("GET", "/swagger/swagger.json", nil))
+	assert.NoError(t, err)
+	raw, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+
+	as_map := map[string]any{}
+	assert.NoError(t, json.Unmarshal(raw, &as_map))
+	return as_map["components"].(map[string]any)["schemas"].(map[string]any)
+}
+
+func TestGeneratedSchemasMatchReflection(t *testing.T) {
+	types := []reflect.Type{reflect.TypeFor[models.Order](), reflect.TypeFor[models.User](), reflect.TypeFor[models.Review]()}
+	comments, err := gofiberswagger.ParseDocComments("./testdata/models")
+	assert.NoError(t, err)
+
+	configs := map[string]gofiberswagger.Config{
+		"short names":             {SchemaNamingStrategy: gofiberswagger.ShortSchemaNames},
+		"package qualified names": {SchemaNamingStrategy: gofiberswagger.PackageQualifiedSchemaNames},
+		"doc comments":            {SchemaNamingStrategy: gofiberswagger.ShortSchemaNames, DocComments: comments},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			reflected := documentComponents(t, gofiberswagger.NewGenerator(config), types...)
+
+			generator := gofiberswagger.NewGenerator(config)
+			generator.RegisterGeneratedSchemas(models.GeneratedSwaggerSchemas...)
+			generated := documentComponents(t, generator, types...)
+
+			assert.Len(t, generated, 7)
+			assert.Equal(t, reflected, generated)
+			if config.DocComments != nil {
+				user := generated["User"].(map[string]any)
+				assert.Equal(t, "User of the app.", user["description"])
+				assert.Equal(t, "Set by the database.", user["properties"].(map[string]any)["id"].(map[string]any)["description"])
+			}
+		})
+	}
+}
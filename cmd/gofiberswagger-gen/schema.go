@@ -0,0 +1,462 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/types"
+	"log"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Methods changing how gofiberswagger documents a type (ISwaggerSchema, json.Marshaler, encoding.TextMarshaler, ISwaggerEnum),
+// structs implementing any of them are left to the reflection.
+var reflectedMethods = []string{"SwaggerSchema", "MarshalJSON", "MarshalText", "EnumValues"}
+
+// Tags handled by the generator, structs with fields using any other tag known to gofiberswagger are left to the reflection.
+var reflectedTags = []string{"swaggertype", "xml"}
+
+type generatedStruct struct {
+	name     string
+	fields   []generatedField
+	required []string
+}
+
+// generatedField is a property of the struct, either documented statically (schema) or resolved at runtime (resolved).
+type generatedField struct {
+	property string
+	goName   string
+	schema   *staticSchema
+	resolved types.Type
+	// `validate:"required"` pointers of resolved types
+	notNullable bool
+}
+
+// staticSchema is the subset of the schema keywords the generator emits, mirroring what gofiberswagger generates for the basic kinds.
+type staticSchema struct {
+	typeName    string
+	format      string
+	title       string
+	description string
+	pattern     string
+	min, max    string
+	defaultVal  string
+	example     string
+	nullable    bool
+	deprecated  bool
+	readOnly    bool
+	writeOnly   bool
+}
+
+// generatePackage returns the structs of the package which can be documented statically, and why the others can't.
+func generatePackage(pkg *types.Package, only map[string]bool) ([]generatedStruct, []string) {
+	generated, skipped := []generatedStruct{}, []string{}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if len(only) > 0 && !only[name] {
+			continue
+		}
+		type_name, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || type_name.IsAlias() {
+			continue
+		}
+		named, ok := type_name.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+
+		result, err := generateStruct(named)
+		if err != nil {
+			skipped = append(skipped, name+": "+err.Error())
+			continue
+		}
+		generated = append(generated, result)
+	}
+	return generated, skipped
+}
+
+func generateStruct(named *types.Named) (generatedStruct, error) {
+	result := generatedStruct{name: named.Obj().Name(), required: []string{}}
+	if named.TypeParams().Len() > 0 {
+		return result, errors.New("generic structs are not supported")
+	}
+	methods := types.NewMethodSet(types.NewPointer(named))
+	for _, method := range reflectedMethods {
+		if methods.Lookup(named.Obj().Pkg(), method) != nil {
+			return result, errors.New("implements " + method)
+		}
+	}
+
+	structure := named.Underlying().(*types.Struct)
+	if structure.NumFields() == 0 {
+		return result, errors.New("has no fields")
+	}
+
+	name_tags := fieldNameTags(structure)
+	seen := map[string]bool{}
+	for i := range structure.NumFields() {
+		field := structure.Field(i)
+		tag := reflect.StructTag(structure.Tag(i))
+		if field.Embedded() {
+			return result, errors.New(field.Name() + " is embedded")
+		}
+		if !field.Exported() || isIgnoredField(tag, name_tags) {
+			continue
+		}
+		for _, key := range reflectedTags {
+			if _, ok := tag.Lookup(key); ok {
+				return result, errors.New(field.Name() + " uses the " + key + " tag")
+			}
+		}
+
+		required := false
+		if validate := tag.Get("validate"); validate != "" {
+			if validate != "required" {
+				return result, errors.New(field.Name() + " uses validate tags other than required")
+			}
+			required = true
+		}
+
+		generated, err := generateField(field, tag, name_tags)
+		if err != nil {
+			return result, errors.New(field.Name() + " " + err.Error())
+		}
+		if generated == nil {
+			continue
+		}
+		if seen[generated.property] {
+			return result, errors.New(field.Name() + " conflicts with another field named " + generated.property)
+		}
+		seen[generated.property] = true
+
+		if required {
+			result.required = append(result.required, generated.property)
+			if generated.schema != nil {
+				generated.schema.nullable = false
+			} else {
+				generated.notNullable = true
+			}
+		}
+		result.fields = append(result.fields, *generated)
+	}
+	return result, nil
+}
+
+// generateField mirrors generateFieldSchema and parseTags of gofiberswagger for the basic kinds,
+// fields of other types get resolved at runtime, as long as they don't use tags changing their schema.
+func generateField(field *types.Var, tag reflect.StructTag, name_tags []string) (*generatedField, error) {
+	result := &generatedField{property: schemaFieldName(field.Name(), tag, name_tags), goName: field.Name()}
+
+	field_type, nullable := types.Unalias(field.Type()), false
+	for {
+		pointer, ok := field_type.(*types.Pointer)
+		if !ok {
+			break
+		}
+		field_type, nullable = types.Unalias(pointer.Elem()), true
+	}
+
+	switch underlying := field_type.Underlying().(type) {
+	case *types.Signature, *types.Chan:
+		// can't be represented, gofiberswagger skips them as well
+		return nil, nil
+	case *types.Basic:
+		if underlying.Kind() == types.UnsafePointer {
+			return nil, nil
+		}
+	}
+
+	basic, is_basic := field_type.(*types.Basic)
+	if !is_basic {
+		if hasSchemaTags(tag) {
+			return nil, errors.New("of a non-basic type uses tags changing its schema")
+		}
+		result.resolved = field.Type()
+		return result, nil
+	}
+
+	schema, err := basicSchema(basic)
+	if err != nil {
+		return nil, err
+	}
+	schema.nullable = nullable
+	schema.title = result.property
+	applyTags(schema, field.Name(), tag)
+	result.schema = schema
+	return result, nil
+}
+
+// basicSchema mirrors getDefaultSchema of gofiberswagger.
+func basicSchema(basic *types.Basic) (*staticSchema, error) {
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return &staticSchema{typeName: "boolean"}, nil
+	case info&types.IsString != 0:
+		return &staticSchema{typeName: "string"}, nil
+	case info&types.IsFloat != 0:
+		if basic.Kind() == types.Float32 {
+			return &staticSchema{typeName: "number", format: "float", min: "math.SmallestNonzeroFloat32", max: "math.MaxFloat32"}, nil
+		}
+		return &staticSchema{typeName: "number", format: "double", min: "math.SmallestNonzeroFloat64", max: "math.MaxFloat64"}, nil
+	}
+
+	bounds := map[types.BasicKind]*staticSchema{
+		types.Int:    {min: "math.MinInt", max: "math.MaxInt"},
+		types.Int8:   {min: "math.MinInt8", max: "math.MaxInt8"},
+		types.Int16:  {min: "math.MinInt16", max: "math.MaxInt16"},
+		types.Int32:  {min: "math.MinInt32", max: "math.MaxInt32", format: "int32"},
+		types.Int64:  {min: "math.MinInt64", max: "math.MaxInt64", format: "int64"},
+		types.Uint:   {min: "0", max: "math.MaxUint"},
+		types.Uint8:  {min: "0", max: "math.MaxUint8"},
+		types.Uint16: {min: "0", max: "math.MaxUint16"},
+		types.Uint32: {min: "0", max: "math.MaxUint32"},
+		types.Uint64: {min: "0", max: "math.MaxUint64"},
+	}
+	schema, ok := bounds[basic.Kind()]
+	if !ok {
+		return nil, errors.New("is of the unsupported type " + basic.Name())
+	}
+	schema.typeName = "integer"
+	return schema, nil
+}
+
+// applyTags mirrors parseTags and the default tag of gofiberswagger.
+func applyTags(schema *staticSchema, field_name string, tag reflect.StructTag) {
+	if slices.Contains(strings.Split(tag.Get("json"), ",")[1:], "string") {
+		schema.typeName = "string"
+	}
+	if title, ok := tag.Lookup("title"); ok {
+		schema.title = title
+	}
+	if description, ok := tag.Lookup("description"); ok {
+		schema.description = description
+	}
+	if format, ok := tag.Lookup("format"); ok {
+		schema.format = format
+	}
+	if pattern, ok := tag.Lookup("pattern"); ok {
+		schema.pattern = pattern
+	}
+	if example, ok := tag.Lookup("example"); ok {
+		if literal, ok := tagValueLiteral("example", field_name, example, schema.typeName); ok {
+			schema.example = literal
+		}
+	}
+	if tag.Get("deprecated") == "true" {
+		schema.deprecated = true
+	}
+	if tag.Get("readOnly") == "true" {
+		schema.readOnly = true
+	}
+	if tag.Get("writeOnly") == "true" {
+		schema.writeOnly = true
+	}
+	for _, opt := range strings.Split(tag.Get("swagger"), ",") {
+		switch strings.TrimSpace(opt) {
+		case "readonly":
+			schema.readOnly = true
+		case "writeonly":
+			schema.writeOnly = true
+		}
+	}
+	if value, ok := tag.Lookup("default"); ok {
+		if literal, ok := tagValueLiteral("default", field_name, value, schema.typeName); ok {
+			schema.defaultVal = literal
+		}
+	}
+}
+
+// tagValueLiteral mirrors tagValue of gofiberswagger, returning the go literal of the parsed value.
+// Unparsable values are left out with a warning, the same way gofiberswagger leaves them out of the docs.
+func tagValueLiteral(tag string, field_name string, raw string, type_name string) (string, bool) {
+	switch type_name {
+	case "integer":
+		if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return "int64(" + strconv.FormatInt(value, 10) + ")", true
+		}
+	case "number":
+		if value, err := strconv.ParseFloat(raw, 64); err == nil {
+			return "float64(" + strconv.FormatFloat(value, 'g', -1, 64) + ")", true
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return strconv.FormatBool(value), true
+		}
+	default:
+		return strconv.Quote(raw), true
+	}
+	log.Println("gofiberswagger-gen: the "+tag+" tag \""+raw+"\" of the field", field_name, "doesn't match its type, leaving it out.")
+	return "", false
+}
+
+// hasSchemaTags reports whether the field uses tags which would change the schema of its type.
+func hasSchemaTags(tag reflect.StructTag) bool {
+	for _, key := range []string{"title", "description", "format", "pattern", "example", "deprecated", "readOnly", "writeOnly", "swagger", "default"} {
+		if _, ok := tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return slices.Contains(strings.Split(tag.Get("json"), ",")[1:], "string")
+}
+
+// fieldNameTags mirrors fieldNameTags of gofiberswagger (embedded structs aren't generated, so only the fields of the struct matter).
+func fieldNameTags(structure *types.Struct) []string {
+	for i := range structure.NumFields() {
+		if tag, ok := reflect.StructTag(structure.Tag(i)).Lookup("json"); ok && tag != "-" {
+			return []string{"json"}
+		}
+	}
+	return []string{"form", "query", "json"}
+}
+
+// schemaFieldName mirrors schemaFieldName of gofiberswagger (the first of the name tags, the go name otherwise).
+func schemaFieldName(name string, tag reflect.StructTag, name_tags []string) string {
+	for _, tag_name := range name_tags {
+		if parts := strings.Split(tag.Get(tag_name), ","); parts[0] != "" && parts[0] != "-" {
+			return parts[0]
+		}
+	}
+	return name
+}
+
+// isIgnoredField mirrors isIgnoredField of gofiberswagger (xml tags are left to the reflection).
+func isIgnoredField(tag reflect.StructTag, name_tags []string) bool {
+	if tag.Get("swaggerignore") == "true" {
+		return true
+	}
+	for _, tag_name := range name_tags {
+		if value, ok := tag.Lookup(tag_name); ok {
+			return strings.Split(value, ",")[0] == "-"
+		}
+	}
+	return false
+}
+
+/// ---- Rendering ---- ///
+
+const gofiberswaggerImport = "github.com/TDiblik/gofiber-swagger/gofiberswagger"
+
+// renderPackage renders the generated file: a GeneratedSwaggerSchemas table, registered to the default generator by init.
+func renderPackage(pkg *types.Package, structs []generatedStruct) ([]byte, error) {
+	imports := map[string]string{"reflect": "reflect", "math": "math", gofiberswaggerImport: "gofiberswagger"}
+	// identifiers of the generated code, packages named the same way get an alias
+	reserved := []string{"schema", "ref", "property"}
+	qualifier := func(other *types.Package) string {
+		if other.Path() == pkg.Path() {
+			return ""
+		}
+		if name, ok := imports[other.Path()]; ok {
+			return name
+		}
+		name := other.Name()
+		for taken := 2; slices.Contains(mapValues(imports), name) || slices.Contains(reserved, name); taken++ {
+			name = other.Name() + strconv.Itoa(taken)
+		}
+		imports[other.Path()] = name
+		return name
+	}
+
+	body := &bytes.Buffer{}
+	uses_math := false
+	fmt.Fprintf(body, "// GeneratedSwaggerSchemas are the schemas of the structs of this package, registered to the default generator by init.\n")
+	fmt.Fprintf(body, "// Pass them to Generator.RegisterGeneratedSchemas when using your own gofiberswagger.Generator.\n")
+	fmt.Fprintf(body, "var GeneratedSwaggerSchemas = []gofiberswagger.GeneratedSchema{\n")
+	for _, structure := range structs {
+		fmt.Fprintf(body, "{Type: reflect.TypeFor[%s](), Build: generatedSwaggerSchema%s},\n", structure.name, structure.name)
+	}
+	fmt.Fprintf(body, "}\n\nfunc init() {\ngofiberswagger.RegisterGeneratedSchemas(GeneratedSwaggerSchemas...)\n}\n")
+
+	for _, structure := range structs {
+		fmt.Fprintf(body, "\nfunc generatedSwaggerSchema%s(ref gofiberswagger.SchemaResolver) *gofiberswagger.Schema {\n", structure.name)
+		fmt.Fprintf(body, "schema := &gofiberswagger.Schema{Type: &gofiberswagger.Types{\"object\"}, Properties: gofiberswagger.Schemas{}, Required: %#v}\n", structure.required)
+		for _, field := range structure.fields {
+			if field.schema == nil {
+				fmt.Fprintf(body, "schema.Properties[%q] = ref(reflect.TypeFor[%s](), %q, %q)\n", field.property, types.TypeString(field.resolved, qualifier), field.goName, field.property)
+				if field.notNullable {
+					fmt.Fprintf(body, "if property := schema.Properties[%q]; property != nil {\nproperty.Value.Nullable = false\n}\n", field.property)
+				}
+				continue
+			}
+			literal, math_bounds := renderSchema(field.schema)
+			uses_math = uses_math || math_bounds
+			fmt.Fprintf(body, "schema.Properties[%q] = &gofiberswagger.SchemaRef{Value: %s}\n", field.property, literal)
+		}
+		fmt.Fprintf(body, "return schema\n}\n")
+	}
+	fmt.Fprintf(body, "\nfunc generatedSwaggerBound(value float64) *float64 {\nreturn &value\n}\n")
+
+	if !uses_math {
+		delete(imports, "math")
+	}
+	source := &bytes.Buffer{}
+	fmt.Fprintf(source, "// Code generated by gofiberswagger-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	// the standard library first, same as goimports
+	for _, standard := range []bool{true, false} {
+		for _, path := range sortedKeys(imports) {
+			if strings.Contains(strings.Split(path, "/")[0], ".") == standard {
+				continue
+			}
+			if name := imports[path]; name == path[strings.LastIndex(path, "/")+1:] {
+				fmt.Fprintf(source, "%q\n", path)
+			} else {
+				fmt.Fprintf(source, "%s %q\n", name, path)
+			}
+		}
+		fmt.Fprintf(source, "\n")
+	}
+	fmt.Fprintf(source, ")\n\n")
+	source.Write(body.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, errors.Join(errors.New("gofiberswagger-gen: unable to format the generated file -> "), err)
+	}
+	return formatted, nil
+}
+
+func renderSchema(schema *staticSchema) (string, bool) {
+	fields := []string{fmt.Sprintf("Type: &gofiberswagger.Types{%q}", schema.typeName)}
+	add := func(condition bool, field string) {
+		if condition {
+			fields = append(fields, field)
+		}
+	}
+	add(schema.format != "", fmt.Sprintf("Format: %q", schema.format))
+	add(schema.title != "", fmt.Sprintf("Title: %q", schema.title))
+	add(schema.description != "", fmt.Sprintf("Description: %q", schema.description))
+	add(schema.pattern != "", fmt.Sprintf("Pattern: %q", schema.pattern))
+	add(schema.min != "", "Min: generatedSwaggerBound("+schema.min+")")
+	add(schema.max != "", "Max: generatedSwaggerBound("+schema.max+")")
+	add(schema.defaultVal != "", "Default: "+schema.defaultVal)
+	add(schema.example != "", "Example: "+schema.example)
+	add(schema.nullable, "Nullable: true")
+	add(schema.deprecated, "Deprecated: true")
+	add(schema.readOnly, "ReadOnly: true")
+	add(schema.writeOnly, "WriteOnly: true")
+	return "&gofiberswagger.Schema{" + strings.Join(fields, ", ") + "}", strings.Contains(schema.min+schema.max, "math.")
+}
+
+func mapValues(values map[string]string) []string {
+	result := []string{}
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+// Code generated by gofiberswagger-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/google/uuid"
+)
+
+// GeneratedSwaggerSchemas are the schemas of the structs of this package, registered to the default generator by init.
+// Pass them to Generator.RegisterGeneratedSchemas when using your own gofiberswagger.Generator.
+var GeneratedSwaggerSchemas = []gofiberswagger.GeneratedSchema{
+	{Type: reflect.TypeFor[Order](), Build: generatedSwaggerSchemaOrder},
+	{Type: reflect.TypeFor[Profile](), Build: generatedSwaggerSchemaProfile},
+	{Type: reflect.TypeFor[User](), Build: generatedSwaggerSchemaUser},
+}
+
+func init() {
+	gofiberswagger.RegisterGeneratedSchemas(GeneratedSwaggerSchemas...)
+}
+
+func generatedSwaggerSchemaOrder(ref gofiberswagger.SchemaResolver) *gofiberswagger.Schema {
+	schema := &gofiberswagger.Schema{Type: &gofiberswagger.Types{"object"}, Properties: gofiberswagger.Schemas{}, Required: []string{}}
+	schema.Properties["buyer"] = ref(reflect.TypeFor[User](), "Buyer", "buyer")
+	schema.Properties["total"] = ref(reflect.TypeFor[Money](), "Total", "total")
+	schema.Properties["validated"] = ref(reflect.TypeFor[Validated](), "Validated", "validated")
+	schema.Properties["embedding"] = ref(reflect.TypeFor[Embedding](), "Embedding", "embedding")
+	return schema
+}
+
+func generatedSwaggerSchemaProfile(ref gofiberswagger.SchemaResolver) *gofiberswagger.Schema {
+	schema := &gofiberswagger.Schema{Type: &gofiberswagger.Types{"object"}, Properties: gofiberswagger.Schemas{}, Required: []string{}}
+	schema.Properties["bio"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"string"}, Title: "bio", Pattern: "^[a-z]+$"}}
+	schema.Properties["owner"] = ref(reflect.TypeFor[*User](), "Owner", "owner")
+	schema.Properties["links"] = ref(reflect.TypeFor[[]string](), "Links", "links")
+	schema.Properties["rank"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"integer"}, Title: "rank", Min: generatedSwaggerBound(math.MinInt), Max: generatedSwaggerBound(math.MaxInt)}}
+	return schema
+}
+
+func generatedSwaggerSchemaUser(ref gofiberswagger.SchemaResolver) *gofiberswagger.Schema {
+	schema := &gofiberswagger.Schema{Type: &gofiberswagger.Types{"object"}, Properties: gofiberswagger.Schemas{}, Required: []string{"id", "name", "profile"}}
+	schema.Properties["id"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"integer"}, Format: "int64", Title: "id", Min: generatedSwaggerBound(math.MinInt64), Max: generatedSwaggerBound(math.MaxInt64), ReadOnly: true}}
+	schema.Properties["name"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"string"}, Title: "name", Description: "Full name", Example: "John"}}
+	schema.Properties["email"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"string"}, Format: "email", Title: "email", Nullable: true}}
+	schema.Properties["age"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"integer"}, Title: "age", Min: generatedSwaggerBound(0), Max: generatedSwaggerBound(math.MaxUint8), Default: int64(18)}}
+	schema.Properties["score"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"number"}, Format: "float", Title: "score", Min: generatedSwaggerBound(math.SmallestNonzeroFloat32), Max: generatedSwaggerBound(math.MaxFloat32), Example: float64(4.5)}}
+	schema.Properties["count"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"string"}, Title: "count", Min: generatedSwaggerBound(math.MinInt), Max: generatedSwaggerBound(math.MaxInt)}}
+	schema.Properties["admin"] = &gofiberswagger.SchemaRef{Value: &gofiberswagger.Schema{Type: &gofiberswagger.Types{"boolean"}, Title: "admin", Deprecated: true}}
+	schema.Properties["status"] = ref(reflect.TypeFor[Status](), "Status", "status")
+	schema.Properties["profile"] = ref(reflect.TypeFor[*Profile](), "Profile", "profile")
+	if property := schema.Properties["profile"]; property != nil {
+		property.Value.Nullable = false
+	}
+	schema.Properties["tags"] = ref(reflect.TypeFor[[]string](), "Tags", "tags")
+	schema.Properties["labels"] = ref(reflect.TypeFor[map[string]int](), "Labels", "labels")
+	schema.Properties["created_at"] = ref(reflect.TypeFor[time.Time](), "CreatedAt", "created_at")
+	schema.Properties["ref"] = ref(reflect.TypeFor[uuid.UUID](), "Ref", "ref")
+	schema.Properties["avatar"] = ref(reflect.TypeFor[[]byte](), "Avatar", "avatar")
+	schema.Properties["address"] = ref(reflect.TypeFor[struct {
+		City string "json:\"city\""
+	}](), "Address", "address")
+	return schema
+}
+
+func generatedSwaggerBound(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,81 @@
+// Package models is used by the tests of gofiberswagger-gen, the generated schemas have to match the reflected ones.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+func (Status) EnumValues() []any {
+	return []any{"active", "disabled"}
+}
+
+// User of the app.
+type User struct {
+	// Set by the database.
+	Id        int64          `json:"id" swagger:"readonly" validate:"required"`
+	Name      string         `json:"name" validate:"required" description:"Full name" example:"John"`
+	Email     *string        `json:"email" format:"email"`
+	Age       uint8          `json:"age,omitempty" default:"18"`
+	Score     float32        `json:"score" example:"4.5"`
+	Count     int            `json:"count,string"`
+	Admin     bool           `json:"admin" deprecated:"true"`
+	Status    Status         `json:"status"`
+	Profile   *Profile       `json:"profile" validate:"required"`
+	Tags      []string       `json:"tags"`
+	Labels    map[string]int `json:"labels"`
+	CreatedAt time.Time      `json:"created_at"`
+	Ref       uuid.UUID      `json:"ref"`
+	Avatar    []byte         `json:"avatar"`
+	Address   struct {
+		City string `json:"city"`
+	} `json:"address"`
+	Secret   string `json:"-"`
+	Internal string `swaggerignore:"true"`
+	Callback func() `json:"callback"`
+	private  string
+}
+
+type Profile struct {
+	// Short introduction.
+	Bio   string   `json:"bio" pattern:"^[a-z]+$"`
+	Owner *User    `json:"owner"`
+	Links []string `json:"links"`
+	Rank  int      `json:"rank" example:"first"`
+}
+
+// Reflected, since it uses validate tags the generator doesn't translate.
+type Validated struct {
+	Email string `json:"email" validate:"email"`
+}
+
+// Reflected, since it embeds another struct.
+type Embedding struct {
+	Profile
+	Extra string `json:"extra"`
+}
+
+// Reflected, since it documents itself.
+type Money struct {
+	Amount int64 `json:"amount"`
+}
+
+func (Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"0"`), nil
+}
+
+type Order struct {
+	Buyer     User      `json:"buyer"`
+	Total     Money     `json:"total"`
+	Validated Validated `json:"validated"`
+	Embedding Embedding `json:"embedding"`
+}
+
+// Reflected, since its readonly field references a component.
+type Review struct {
+	Author *User  `json:"author" swagger:"readonly"`
+	Text   string `json:"text"`
+}
@@ -11,6 +11,10 @@ import (
 func main() {
 	app := fiber.New()
 
+	// Components get named after the package + type by default, short names ("User", "PageUser" for Page[User]) are opt-in.
+	// Schemas are generated as the routes get created, so the strategy has to be set before that.
+	gofiberswagger.SetSchemaNamingStrategy(gofiberswagger.ShortSchemaNames)
+
 	// Create wrapper around the fiber router
 	router := gofiberswagger.NewRouter(app)
 	router.Get("/", nil, HelloHandler)
@@ -26,7 +30,7 @@ func main() {
 			},
 		},
 		SwaggerUI: gofiberswagger.SwaggerUIConfig{
-			URL:    "/swagger/swagger.yaml",
+			// URL left empty, so it points to the yaml served under BasePath
 			Title:  "Swagger UI - title of the swagger UI page",
 			Layout: "StandaloneLayout",
 			Plugins: []template.JS{
@@ -55,12 +59,23 @@ func main() {
 		FilterOutAppUse:          true,
 		RequiredAuth:             nil,
 		AutomaticallyRequireAuth: false,
+		SchemaNamingStrategy:     gofiberswagger.ShortSchemaNames,
+		// Routes without a manually set OperationID get named after their handler ("HelloHandler" here)
+		OperationIdStrategy: gofiberswagger.OperationIdFromHandlerName,
+		// Serve a 3.0 document (`nullable: true` instead of type arrays, ...), overrides Swagger.OpenAPI
+		OpenAPIVersion: gofiberswagger.OpenAPIVersion30,
+		// Mount the routes at /docs instead of /swagger, DisableUI / DisableJSON / DisableYAML turn them off separately
+		BasePath:     "/docs",
+		JSONFileName: "openapi.json",
+		YAMLFileName: "openapi.yaml",
+		// Ask for credentials before showing the docs, use Middlewares for your own checks (IP allowlist, session, ...)
+		BasicAuthUsers: map[string]string{"admin": "admin"},
 	})
 
-	// You can now see your:
-	// - UI at /swagger/
-	// - json at /swagger/swagger.json
-	// - yaml at /swagger/swagger.yaml
+	// You can now see your (after logging in as admin / admin):
+	// - UI at /docs/
+	// - json at /docs/openapi.json
+	// - yaml at /docs/openapi.yaml
 
 	log.Fatal(app.Listen(":3000"))
 }
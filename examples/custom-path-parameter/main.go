@@ -22,9 +22,35 @@ func main() {
 		return c.SendString("Update ID: " + c.Params("id"))
 	})
 
+	// Fiber route constraints get translated into the parameter schema automatically (integer with minimum 1 here),
+	// and optional parameters get documented as two paths: /files/{name} and /files
+	router.Get("/users/:id<int;min(1)>", nil, func(c fiber.Ctx) error {
+		return c.SendString("User ID: " + c.Params("id"))
+	})
+	router.Get("/files/:name?", nil, func(c fiber.Ctx) error {
+		return c.SendString("File: " + c.Params("name", "index"))
+	})
+
+	// Parameters can also be generated from the struct you bind them into
+	router.Get("/search", &gofiberswagger.RouteInfo{
+		Parameters: gofiberswagger.NewParametersFromStruct[SearchFilters](),
+	}, func(c fiber.Ctx) error {
+		filters := new(SearchFilters)
+		if err := c.Bind().Query(filters); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return c.JSON(filters)
+	})
+
 	gofiberswagger.Register(app, gofiberswagger.DefaultConfig)
 
 	log.Println("Server started on http://localhost:3000")
 	log.Println("Swagger UI available at http://localhost:3000/swagger/")
 	log.Fatal(app.Listen(":3000"))
 }
+
+type SearchFilters struct {
+	Query string `query:"q" validate:"required"`
+	Page  int    `query:"page" validate:"min=1"`
+	Limit int    `query:"limit" validate:"min=1,max=100"`
+}
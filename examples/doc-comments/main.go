@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/gofiber/fiber/v3"
+)
+
+func main() {
+	// Parse the doc comments of the source (run from the root of the repository, eg. `make doc-comments`).
+	// When the source isn't available at runtime, generate an index using
+	// `go run github.com/TDiblik/gofiber-swagger/cmd/gofiberswagger-comments -dir . -o comments.json`
+	// and load it using gofiberswagger.LoadDocComments("comments.json") instead.
+	comments, err := gofiberswagger.ParseDocComments("./examples/doc-comments")
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Schemas get generated as the routes get created, so the comments have to be set before that.
+	gofiberswagger.SetDocComments(comments)
+
+	app := fiber.New()
+	router := gofiberswagger.NewRouter(app)
+
+	router.Post("/users", &gofiberswagger.RouteInfo{
+		RequestBody: gofiberswagger.NewRequestBody[CreateUserRequest](),
+	}, CreateUser)
+
+	gofiberswagger.Register(app, gofiberswagger.DefaultConfig)
+
+	log.Println("Server started on http://localhost:3000")
+	log.Println("Swagger UI available at http://localhost:3000/swagger/")
+	log.Fatal(app.Listen(":3000"))
+}
+
+// CreateUserRequest holds everything needed to create a user.
+type CreateUserRequest struct {
+	// Unique name used to log in.
+	Username string `json:"username" validate:"required"`
+	Email    string `json:"email"` // Email used for notifications.
+}
+
+// CreateUser creates a new user. The summary is the first sentence of this comment.
+//
+// The whole comment ends up in the description.
+func CreateUser(c fiber.Ctx) error {
+	return c.SendStatus(201)
+}
@@ -0,0 +1,86 @@
+package main
+
+// In your project, put this into ./cmd/export-openapi/main.go and call the same function which registers your routes
+// (without connecting to the database or starting the server), then:
+//
+//	go run ./cmd/export-openapi -o ./openapi.json          // write the spec, eg. to commit it
+//	go run ./cmd/export-openapi -o ./openapi.json -check   // fail when the committed spec is out of date (eg. in CI)
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/gofiber/fiber/v3"
+)
+
+func main() {
+	output := flag.String("o", "./openapi.json", "file to write the spec into")
+	check := flag.Bool("check", false, "only check that the file is up to date")
+	flag.Parse()
+
+	app := fiber.New()
+	SetupRoutes(gofiberswagger.NewRouter(app))
+
+	// Builds the same document gofiberswagger.Register serves, without mounting /swagger or calling Listen
+	exported := &bytes.Buffer{}
+	if err := gofiberswagger.Export(app, gofiberswagger.DefaultConfig, exported); err != nil {
+		log.Fatal(err)
+	}
+
+	if *check {
+		committed, err := os.ReadFile(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !bytes.Equal(committed, exported.Bytes()) {
+			log.Fatalf("%s is out of date, run `go run ./cmd/export-openapi -o %s`", *output, *output)
+		}
+		log.Println(*output, "is up to date")
+		return
+	}
+
+	if err := os.WriteFile(*output, exported.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("exported the spec into", *output)
+}
+
+// SetupRoutes registers the routes of the app, shared by the server and the export command.
+// The handlers can depend on a database, since they don't get called while exporting.
+func SetupRoutes(router gofiberswagger.SwaggerRouter) {
+	router.Get("/users/:id", &gofiberswagger.RouteInfo{
+		Responses: gofiberswagger.NewResponses(
+			gofiberswagger.NewResponseInfo[User]("200", "the user"),
+		),
+	}, GetUserHandler)
+	router.Post("/users", &gofiberswagger.RouteInfo{
+		RequestBody: gofiberswagger.NewRequestBodyJSON[CreateUserRequest](),
+		Responses: gofiberswagger.NewResponses(
+			gofiberswagger.NewResponseInfo[User]("200", "the created user"),
+		),
+	}, CreateUserHandler)
+}
+
+type User struct {
+	Id   string `json:"id" format:"uuid"`
+	Name string `json:"name" example:"John"`
+}
+
+type CreateUserRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func GetUserHandler(c fiber.Ctx) error {
+	return c.JSON(User{Id: c.Params("id")})
+}
+
+func CreateUserHandler(c fiber.Ctx) error {
+	request := CreateUserRequest{}
+	if err := c.Bind().Body(&request); err != nil {
+		return err
+	}
+	return c.JSON(User{Name: request.Name})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"log"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/gofiber/fiber/v3"
+)
+
+func main() {
+	public_app := fiber.New()
+	admin_app := fiber.New()
+
+	// Each app gets its own generator, so their routes and schemas don't bleed into each other.
+	// The top-level functions (gofiberswagger.NewRouter, gofiberswagger.Register, ...) share a single default generator.
+	public_generator := gofiberswagger.NewGenerator(gofiberswagger.DefaultConfig)
+	public_router := public_generator.NewRouter(public_app)
+	public_router.Get("/users", &gofiberswagger.RouteInfo{
+		Responses: gofiberswagger.NewResponses(
+			gofiberswagger.NewResponseInfo[PublicUser]("200", "public user"),
+		),
+	}, HelloHandler)
+
+	admin_config := gofiberswagger.DefaultConfig
+	admin_config.SwaggerFilesPath = "./generated/swagger-admin"
+	admin_generator := gofiberswagger.NewGenerator(admin_config)
+	admin_router := admin_generator.NewRouter(admin_app)
+	admin_router.Get("/users", &gofiberswagger.RouteInfo{
+		Responses: gofiberswagger.NewResponses(
+			gofiberswagger.NewResponseInfo[AdminUser]("200", "admin user"),
+		),
+	}, HelloHandler)
+
+	// You can now see your:
+	// - public UI at :3000/swagger/
+	// - admin UI at :3001/swagger/
+	if err := public_generator.Register(public_app); err != nil {
+		log.Fatal(err)
+	}
+	if err := admin_generator.Register(admin_app); err != nil {
+		log.Fatal(err)
+	}
+
+	go func() {
+		log.Fatal(admin_app.Listen(":3001"))
+	}()
+	log.Fatal(public_app.Listen(":3000"))
+}
+
+// ----- Hello Handler and it's types ----- //
+func HelloHandler(c fiber.Ctx) error {
+	return c.SendStatus(200)
+}
+
+type PublicUser struct {
+	Name string `json:"name"`
+}
+
+type AdminUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
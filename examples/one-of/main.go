@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/gofiber/fiber/v3"
+)
+
+type PaymentMethod interface {
+	isPaymentMethod()
+}
+
+type Card struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+func (Card) isPaymentMethod() {}
+
+type BankTransfer struct {
+	Type string `json:"type"`
+	Iban string `json:"iban"`
+}
+
+func (BankTransfer) isPaymentMethod() {}
+
+type CreateOrderRequest struct {
+	Payment PaymentMethod `json:"payment"`
+}
+
+func main() {
+	// Fields of the PaymentMethod type get documented as oneOf Card / BankTransfer, with the "type" property as the discriminator
+	// ("Card" / "BankTransfer"). Use RegisterOneOfWithDiscriminator for a custom property name and values.
+	// Schemas get generated as the routes get created, so register the interface before that.
+	gofiberswagger.RegisterOneOf[PaymentMethod](Card{}, BankTransfer{})
+
+	app := fiber.New()
+	router := gofiberswagger.NewRouter(app)
+
+	router.Post("/orders", &gofiberswagger.RouteInfo{
+		RequestBody: gofiberswagger.NewRequestBody[CreateOrderRequest](),
+	}, func(c fiber.Ctx) error {
+		return c.SendStatus(201)
+	})
+
+	gofiberswagger.Register(app, gofiberswagger.DefaultConfig)
+
+	log.Println("Server started on http://localhost:3000")
+	log.Println("Swagger UI available at http://localhost:3000/swagger/")
+	log.Fatal(app.Listen(":3000"))
+}
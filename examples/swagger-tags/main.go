@@ -19,12 +19,22 @@ type ExampleRequest struct {
 
 	// This field's type will be overridden to an array of integers.
 	OverriddenArray []string `json:"overridden_array" swaggertype:"[]integer"`
+
+	// Documentation tags. The example and the default get parsed into the field's type (42 instead of "42").
+	Email    string `json:"email" description:"Email used for notifications" format:"email" example:"john@example.com"`
+	Username string `json:"username" title:"Login" pattern:"^[a-z]+$"`
+	Age      int    `json:"age" example:"42" default:"18"`
+	Password string `json:"password" writeOnly:"true"`
+	Nickname string `json:"nickname" deprecated:"true"`
 }
 
 // ExampleResponse struct demonstrating the swagger tags
 type ExampleResponse struct {
 	// Even though this is an array of strings in Go, we override it to be an "object" in the Swagger documentation.
 	Data []string `json:"data" swaggertype:"object"`
+
+	// Only ever returned by the server.
+	Id int `json:"id" readOnly:"true" example:"1"`
 }
 
 func main() {
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"log"
+
+	"github.com/TDiblik/gofiber-swagger/gofiberswagger"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+)
+
+func main() {
+	app := fiber.New()
+	router := gofiberswagger.NewRouter(app)
+
+	// The request body and the response get documented from the handler's types,
+	// so they can't drift from what the handler actually binds and returns.
+	// Documenting "201" makes it the status the response gets documented and sent with, instead of 200.
+	gofiberswagger.Post(router, "/users", &gofiberswagger.RouteInfo{
+		Summary: "Create a user",
+		Responses: gofiberswagger.NewResponsesRaw(map[string]*gofiberswagger.ResponseRef{
+			"201": {Value: openapi3.NewResponse().WithDescription("Created")},
+		}),
+	}, CreateUserHandler)
+
+	// Handlers without a request body only infer the response
+	gofiberswagger.Get(router, "/users/:id", nil, GetUserHandler)
+
+	// Returning NoContent documents (and sends) 204 without a body
+	gofiberswagger.Delete(router, "/users/:id", nil, DeleteUserHandler)
+
+	// You can now see your:
+	// - UI at /swagger/
+	// - json at /swagger/swagger.json
+	// - yaml at /swagger/swagger.yaml
+	gofiberswagger.Register(app, gofiberswagger.DefaultConfig)
+
+	log.Fatal(app.Listen(":3000"))
+}
+
+// ----- Handlers and their types ----- //
+type CreateUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required"`
+}
+
+type UserResponse struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func CreateUserHandler(c fiber.Ctx, req *CreateUserRequest) (UserResponse, error) {
+	return UserResponse{Id: "1", Name: req.Name, Email: req.Email}, nil
+}
+
+func GetUserHandler(c fiber.Ctx) (UserResponse, error) {
+	if c.Params("id") != "1" {
+		return UserResponse{}, fiber.ErrNotFound
+	}
+	return UserResponse{Id: "1", Name: "John", Email: "john@example.com"}, nil
+}
+
+func DeleteUserHandler(c fiber.Ctx) (gofiberswagger.NoContent, error) {
+	if c.Params("id") != "1" {
+		return gofiberswagger.NoContent{}, fiber.ErrNotFound
+	}
+	return gofiberswagger.NoContent{}, nil
+}
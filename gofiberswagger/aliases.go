@@ -1,6 +1,8 @@
 package gofiberswagger
 
 import (
+	"reflect"
+
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -103,8 +105,8 @@ func NewRequestBodyFullyCustom[T any](description string, required bool, consume
 	request_body := openapi3.NewRequestBody()
 	request_body.WithDescription(description)
 	request_body.WithRequired(required)
-	schema := CreateSchema[T]()
-	request_body.WithSchemaRef(schema, consumes)
+	request_body.WithSchemaRef(typedSchema[T](), consumes)
+	withTypeExamples[T](request_body.Content)
 	return &RequestBodyRef{Value: request_body}
 }
 
@@ -149,8 +151,8 @@ func NewResponsesRaw(responses map[string]*ResponseRef) *Responses {
 }
 func NewResponseRawJSON[T any](description string) *ResponseRef {
 	response := openapi3.NewResponse()
-	schema := CreateSchema[T]()
-	response.WithJSONSchemaRef(schema)
+	response.WithJSONSchemaRef(typedSchema[T]())
+	withTypeExamples[T](response.Content)
 	response.WithDescription(description)
 	return &ResponseRef{Value: response}
 }
@@ -162,8 +164,7 @@ func NewResponseRaw[T any](mediaType string, description string, additonalMediaT
 		additonalMediaTypeInfo = &MediaType{}
 	}
 	if additonalMediaTypeInfo.Schema == nil {
-		schema := CreateSchema[T]()
-		additonalMediaTypeInfo.Schema = schema
+		additonalMediaTypeInfo.Schema = typedSchema[T]()
 	}
 
 	response.WithContent(
@@ -171,6 +172,7 @@ func NewResponseRaw[T any](mediaType string, description string, additonalMediaT
 			mediaType: additonalMediaTypeInfo,
 		},
 	)
+	withTypeExamples[T](response.Content)
 	response.WithDescription(description)
 	return &ResponseRef{Value: response}
 }
@@ -184,9 +186,16 @@ func NewParameters(parameters ...*ParameterRef) Parameters {
 	return result
 }
 
+// NewParametersFromStruct emits one parameter per field of T tagged with `query`, `params`, `header`, `reqHeader` or `cookie`,
+// so you can reuse the struct you pass to `c.Bind().Query()` & co.
+// The parameters get regenerated by the Generator the route is registered with (see resolveRouteSchemas).
+func NewParametersFromStruct[T any]() Parameters {
+	return defaultGenerator.helperParameters(reflect.TypeFor[T]())
+}
+
 func INewPathParameter[T any](name string) *ParameterRef {
 	param_raw := openapi3.NewPathParameter(name)
-	param_raw.Schema = CreateSchema[T]()
+	param_raw.Schema = typedSchema[T]()
 	return &ParameterRef{Value: param_raw}
 }
 
@@ -208,7 +217,7 @@ func NewPathParameterExtended(name string, schema *Schema) *ParameterRef {
 
 func INewQueryParameter[T any](name string) *ParameterRef {
 	param_raw := openapi3.NewQueryParameter(name)
-	param_raw.Schema = CreateSchema[T]()
+	param_raw.Schema = typedSchema[T]()
 	return &ParameterRef{Value: param_raw}
 }
 
@@ -240,7 +249,7 @@ func NewQueryParameterExtended(name string, schema *Schema) *ParameterRef {
 
 func INewHeaderParameter[T any](name string) *ParameterRef {
 	param_raw := openapi3.NewHeaderParameter(name)
-	param_raw.Schema = CreateSchema[T]()
+	param_raw.Schema = typedSchema[T]()
 	return &ParameterRef{Value: param_raw}
 }
 
@@ -272,7 +281,7 @@ func NewHeaderParameterExtended(name string, schema *Schema) *ParameterRef {
 
 func INewCookieParameter[T any](name string) *ParameterRef {
 	param_raw := openapi3.NewCookieParameter(name)
-	param_raw.Schema = CreateSchema[T]()
+	param_raw.Schema = typedSchema[T]()
 	return &ParameterRef{Value: param_raw}
 }
 
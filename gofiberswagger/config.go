@@ -1,6 +1,11 @@
 package gofiberswagger
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
 
 // https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.1.1.md#openapi-object
 type SwaggerConfig = openapi3.T
@@ -14,10 +19,47 @@ type Config struct {
 	FilterOutAppUse          bool
 	RequiredAuth             *openapi3.SecurityRequirements
 	AutomaticallyRequireAuth bool
+	// Names of the generated components, PackageQualifiedSchemaNames when left nil. Schemas get generated when the routes are created,
+	// before Register / Export get the config, so set it using SetSchemaNamingStrategy (or NewGenerator) before creating them.
+	// Register / Export only apply it while there are no schemas yet, and return an error when it differs from the one they were generated with.
+	SchemaNamingStrategy SchemaNamingStrategy
+	// Generates the operationId of routes which don't have one set manually (none by default),
+	// eg. OperationIdFromHandlerName, OperationIdFromMethodAndPath or your own func.
+	OperationIdStrategy OperationIdStrategy
+	// Go doc comments used for operation summaries / descriptions (handlers) and schema / property descriptions (types, fields),
+	// see ParseDocComments and LoadDocComments. Set them using SetDocComments (or NewGenerator), see SchemaNamingStrategy.
+	DocComments *DocComments
+	// Version of the generated document (OpenAPIVersion30 or OpenAPIVersion31), overrides Swagger.OpenAPI when set.
+	// Schemas get converted when the document is generated, so the same schemas can be served as both versions.
+	OpenAPIVersion OpenAPIVersion
+	// Types with readOnly / writeOnly fields (`swagger:"readonly"`, `swagger:"writeonly"`), used by both request bodies and responses,
+	// get documented as separate components: "UserInput" without the readOnly fields and "UserOutput" without the writeOnly ones.
+	SplitReadWriteSchemas bool
+	// Request bodies / responses without examples (see ISwaggerExamples) get an example synthesized from their schema,
+	// using the `example` tags, enums, formats (uuid, date-time, email, ...), patterns and bounds of the properties.
+	// Synthesized examples which don't match their schema are left out. Off by default.
+	GenerateExamples bool
+	// Path the swagger routes get mounted at, "/swagger" by default.
+	BasePath string
+	// Names of the served (and created, see CreateSwaggerFiles) spec files, "swagger.json" and "swagger.yaml" by default.
+	JSONFileName string
+	YAMLFileName string
+	// The UI, json and yaml routes can be turned off separately, eg. to serve only the json in production.
+	// When left at its default, SwaggerUI.URL points to the served yaml (or json) under BasePath.
+	DisableUI   bool
+	DisableJSON bool
+	DisableYAML bool
+	// Handlers running before every swagger route (UI, json and yaml), eg. basicauth.New(...), an IP allowlist or your own func.
+	Middlewares []any
+	// Requires the secret to open the docs, sent using the "X-Swagger-Secret" header or the "secret" query parameter
+	// (remembered using a cookie, so the UI can load the spec).
+	SharedSecret string
+	// Requires HTTP Basic credentials of one of the users (username -> password) to open the docs.
+	BasicAuthUsers map[string]string
 }
 
 var DefaultSwaggerConfig = SwaggerConfig{
-	OpenAPI: "3.1.1",
+	OpenAPI: string(OpenAPIVersion31),
 	Info: &Info{
 		Title:   DefaultUIConfig.Title,
 		Version: "0.0.1",
@@ -34,11 +76,29 @@ var DefaultConfig = Config{
 	FilterOutAppUse:          true,
 	RequiredAuth:             nil,
 	AutomaticallyRequireAuth: false,
+	SchemaNamingStrategy:     nil,
+	OperationIdStrategy:      nil,
+	DocComments:              nil,
+	OpenAPIVersion:           "",
+	SplitReadWriteSchemas:    false,
+	GenerateExamples:         false,
+	BasePath:                 "/swagger",
+	JSONFileName:             "swagger.json",
+	YAMLFileName:             "swagger.yaml",
+	DisableUI:                false,
+	DisableJSON:              false,
+	DisableYAML:              false,
+	Middlewares:              nil,
+	SharedSecret:             "",
+	BasicAuthUsers:           nil,
 }
 
 func swaggerConfigDefault(config SwaggerConfig) SwaggerConfig {
 	cfg := config
 
+	if cfg.OpenAPI == "" {
+		cfg.OpenAPI = DefaultSwaggerConfig.OpenAPI
+	}
 	if cfg.Info == nil {
 		cfg.Info = DefaultSwaggerConfig.Info
 	}
@@ -49,12 +109,17 @@ func swaggerConfigDefault(config SwaggerConfig) SwaggerConfig {
 		cfg.Info.Version = DefaultSwaggerConfig.Info.Version
 	}
 
-	if cfg.Paths == nil {
-		cfg.Paths = DefaultSwaggerConfig.Paths
+	// the defaults are shared pointers, so every registered app gets its own copy instead of writing into them
+	if cfg.Paths == nil || cfg.Paths == DefaultSwaggerConfig.Paths {
+		cfg.Paths = &Paths{}
 	}
 
-	if cfg.Components == nil {
-		cfg.Components = DefaultSwaggerConfig.Components
+	if cfg.Components == nil || cfg.Components == DefaultSwaggerConfig.Components {
+		components := Components{}
+		if DefaultSwaggerConfig.Components != nil {
+			components = *DefaultSwaggerConfig.Components
+		}
+		cfg.Components = &components
 	}
 	if cfg.Components.Schemas == nil {
 		cfg.Components.Schemas = make(map[string]*SchemaRef)
@@ -62,3 +127,33 @@ func swaggerConfigDefault(config SwaggerConfig) SwaggerConfig {
 
 	return cfg
 }
+
+// swaggerRoutesConfigDefault fills in the mount path and the file names of the swagger routes,
+// points the UI to its assets and to the served spec, unless its URL was changed from the default.
+func swaggerRoutesConfigDefault(config Config) Config {
+	cfg := config
+
+	if cfg.BasePath == "" {
+		cfg.BasePath = DefaultConfig.BasePath
+	}
+	cfg.BasePath = "/" + strings.Trim(cfg.BasePath, "/")
+	if cfg.JSONFileName == "" {
+		cfg.JSONFileName = DefaultConfig.JSONFileName
+	}
+	if cfg.YAMLFileName == "" {
+		cfg.YAMLFileName = DefaultConfig.YAMLFileName
+	}
+
+	cfg.SwaggerUI.AssetsBaseURL = swaggerUIAssetsBaseURL(cfg.SwaggerUI, cfg.BasePath)
+
+	if cfg.SwaggerUI.URL == "" || cfg.SwaggerUI.URL == DefaultUIConfig.URL {
+		switch {
+		case !cfg.DisableYAML:
+			cfg.SwaggerUI.URL = path.Join(cfg.BasePath, cfg.YAMLFileName)
+		case !cfg.DisableJSON:
+			cfg.SwaggerUI.URL = path.Join(cfg.BasePath, cfg.JSONFileName)
+		}
+	}
+
+	return cfg
+}
@@ -22,7 +22,7 @@ type SwaggerUIConfig struct {
 	ConfigURL string `json:"configUrl,omitempty"`
 
 	// The URL pointing to API definition (normally swagger.json or swagger.yaml).
-	// default: "/swagger/swagger.yaml"
+	// default: "/swagger/swagger.yaml", follows Config.BasePath and Config.YAMLFileName (Config.JSONFileName when the yaml is disabled)
 	URL string `json:"url,omitempty"`
 
 	// Enables overriding configuration parameters via URL search params.
@@ -186,6 +186,18 @@ type SwaggerUIConfig struct {
 	// Applies custom JavaScript scripts.
 	// default ""
 	CustomScript template.JS `json:"-"`
+
+	// Loads the UI assets from unpkg.com (and the fonts from Google) instead of serving the ones embedded into the binary.
+	// default: false
+	UseCDN bool `json:"-"`
+
+	// Version of swagger-ui-dist loaded from unpkg.com when UseCDN is set.
+	// default: SwaggerUIVersion (the embedded version)
+	CDNVersion string `json:"-"`
+
+	// URL the UI assets (swagger-ui-bundle.js, swagger-ui-standalone-preset.js, swagger-ui.css and the favicons) get loaded from, eg. your own mirror.
+	// default: "" -> the embedded assets served under Config.BasePath (eg. /swagger/swagger-ui/5.20.5), or unpkg.com when UseCDN is set
+	AssetsBaseURL string `json:"-"`
 }
 
 type FilterConfig struct {
@@ -318,6 +330,8 @@ func swaggerUIConfigDefault(ui_config SwaggerUIConfig) SwaggerUIConfig {
 		cfg.SyntaxHighlight = DefaultUIConfig.SyntaxHighlight
 	}
 
+	cfg.AssetsBaseURL = swaggerUIAssetsBaseURL(cfg, DefaultConfig.BasePath)
+
 	return cfg
 }
 
@@ -328,21 +342,18 @@ const indexPageTmpl string = `
   <head>
     <meta charset="UTF-8">
     <title>{{.Title}}</title>
+    {{- if .UseCDN}}
     <link href="https://fonts.googleapis.com/css?family=Open+Sans:400,700|Source+Code+Pro:300,600|Titillium+Web:400,600,700" rel="stylesheet">
-  	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.20.0/swagger-ui.css" />
-    <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32" />
-    <link rel="icon" type="image/png" href="./favicon-16x16.png" sizes="16x16" />
+    {{- end}}
+  	<link rel="stylesheet" href="{{.AssetsBaseURL}}/swagger-ui.css" />
+    <link rel="icon" type="image/png" href="{{.AssetsBaseURL}}/favicon-32x32.png" sizes="32x32" />
+    <link rel="icon" type="image/png" href="{{.AssetsBaseURL}}/favicon-16x16.png" sizes="16x16" />
     {{- if .CustomStyle}}
       <style>
         body { margin: 0; }
         {{.CustomStyle}}
       </style>
     {{- end}}
-    {{- if .CustomScript}}
-      <script>
-        {{.CustomScript}}
-      </script>
-    {{- end}}
   </head>
   <body>
     <svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" style="position:absolute;width:0;height:0">
@@ -371,58 +382,9 @@ const indexPageTmpl string = `
       </defs>
     </svg>
     <div id="swagger-ui"></div>
- 	<script src="https://unpkg.com/swagger-ui-dist@5.20.0/swagger-ui-bundle.js" crossorigin></script>
- 	<script src="https://unpkg.com/swagger-ui-dist@5.20.0/swagger-ui-standalone-preset.js" crossorigin></script>
-    <script>
-    window.onload = function() {
-      config = {{.}};
-      config.dom_id = '#swagger-ui';
-      config.plugins = [
-        {{- range $plugin := .Plugins }}
-          {{$plugin}},
-        {{- end}}
-      ];
-      config.presets = [
-        {{- range $preset := .Presets }}
-          {{$preset}},
-        {{- end}}
-      ];
-      config.filter = {{.Filter.Value}}
-      config.syntaxHighlight = {{.SyntaxHighlight.Value}}
-      {{if .TagsSorter}}
-        config.tagsSorter = {{.TagsSorter}}
-      {{end}}
-      {{if .OnComplete}}
-        config.onComplete = {{.OnComplete}}
-      {{end}}
-      {{if .RequestInterceptor}}
-        config.requestInterceptor = {{.RequestInterceptor}}
-      {{end}}
-      {{if .ResponseInterceptor}}
-        config.responseInterceptor = {{.ResponseInterceptor}}
-      {{end}}
-      {{if .ModelPropertyMacro}}
-        config.modelPropertyMacro = {{.ModelPropertyMacro}}
-      {{end}}
-      {{if .ParameterMacro}}
-        config.parameterMacro = {{.ParameterMacro}}
-      {{end}}
-
-      const ui = SwaggerUIBundle(config);
-
-      {{if .OAuth}}
-        ui.initOAuth({{.OAuth}});
-      {{end}}
-      {{if .PreauthorizeBasic}}
-        ui.preauthorizeBasic({{.PreauthorizeBasic}});
-      {{end}}
-      {{if .PreauthorizeApiKey}}
-        ui.preauthorizeApiKey({{.PreauthorizeApiKey}});
-      {{end}}
-
-      window.ui = ui
-    }
-    </script>
+ 	<script src="{{.AssetsBaseURL}}/swagger-ui-bundle.js" crossorigin></script>
+ 	<script src="{{.AssetsBaseURL}}/swagger-ui-standalone-preset.js" crossorigin></script>
+    <script src="{{.InitializerURL}}" charset="UTF-8"></script>
   </body>
 </html>
 `
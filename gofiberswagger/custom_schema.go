@@ -0,0 +1,121 @@
+package gofiberswagger
+
+import (
+	"encoding"
+	"encoding/json"
+	"log"
+	"reflect"
+)
+
+/// ---------------------------------------------------------------------------------------- ///
+/// Custom schemas of types, provided by the type itself or registered for types you don't own ///
+/// ---------------------------------------------------------------------------------------- ///
+
+// ISwaggerSchema lets a type say how it should be documented, eg. a Money struct serialized as a decimal string.
+// Both value and pointer receivers are supported. Returning nil falls back to the reflection based schema.
+type ISwaggerSchema interface {
+	SwaggerSchema() *Schema
+}
+
+// RegisterTypeSchema overrides the schema of a type you don't own, eg. `RegisterTypeSchema(reflect.TypeFor[decimal.Decimal](), &Schema{Type: &Types{"string"}, Format: "decimal"})`.
+// Registered schemas take precedence over ISwaggerSchema and the built-in special types (time.Time, uuid.UUID, ...).
+// It's used by the default generator (used by the top-level functions).
+func RegisterTypeSchema(t reflect.Type, schema *Schema) {
+	defaultGenerator.RegisterTypeSchema(t, schema)
+}
+
+// RegisterTypeSchema overrides the schema of a type (see the top-level RegisterTypeSchema).
+// Register the type before creating schemas which use it, already generated schemas don't get updated.
+func (g *Generator) RegisterTypeSchema(t reflect.Type, schema *Schema) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	if g.typeSchemas == nil {
+		g.typeSchemas = make(map[reflect.Type]*Schema)
+	}
+	g.typeSchemas[t] = schema
+}
+
+// getCustomTypeSchema returns a copy of the registered / ISwaggerSchema schema of the type, or nil if it has none.
+func (g *Generator) getCustomTypeSchema(t reflect.Type) *Schema {
+	g.schemasMutex.RLock()
+	registered := g.typeSchemas[t]
+	g.schemasMutex.RUnlock()
+	if registered != nil {
+		schema := *registered
+		return &schema
+	}
+
+	if provided := getSwaggerSchema(t); provided != nil {
+		schema := *provided
+		return &schema
+	}
+	return nil
+}
+
+func implementsSwaggerSchema(t reflect.Type) bool {
+	return implementsInterface(t, reflect.TypeFor[ISwaggerSchema]())
+}
+
+func getSwaggerSchema(t reflect.Type) *Schema {
+	if !implementsSwaggerSchema(t) {
+		return nil
+	}
+
+	var instance ISwaggerSchema
+	schemaInterface := reflect.TypeFor[ISwaggerSchema]()
+	if t.Implements(schemaInterface) {
+		instance = reflect.New(t).Elem().Interface().(ISwaggerSchema)
+	} else {
+		instance = reflect.New(t).Interface().(ISwaggerSchema)
+	}
+	return instance.SwaggerSchema()
+}
+
+/// ---------------------------------------------------------------------------------- ///
+/// Types with custom serialization (encoding.TextMarshaler, json.Marshaler)           ///
+/// ---------------------------------------------------------------------------------- ///
+
+// getMarshalerSchema returns the schema of a type serialized by its own methods, or nil if it's serialized by reflection.
+// It's checked after the custom and the built-in special types, so time.Time, uuid.UUID, ... keep their schemas.
+// json.Marshaler types can serialize into anything, so they're documented as any value (with a warning),
+// encoding.TextMarshaler types get documented as strings. json.Marshaler wins if both are implemented, same as in encoding/json.
+func (g *Generator) getMarshalerSchema(t reflect.Type) *Schema {
+	if implementsInterface(t, reflect.TypeFor[json.Marshaler]()) {
+		// enums get described by their values (see generateSchema)
+		if !implementsSwaggerEnum(t) {
+			g.warnOpaqueMarshaler(t)
+		}
+		return &Schema{}
+	}
+	if implementsInterface(t, reflect.TypeFor[encoding.TextMarshaler]()) {
+		return &Schema{Type: &Types{"string"}}
+	}
+	return nil
+}
+
+// warnOpaqueMarshaler logs (once per type) that the json.Marshaler type couldn't be documented.
+func (g *Generator) warnOpaqueMarshaler(t reflect.Type) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	if g.marshalerWarnings == nil {
+		g.marshalerWarnings = make(map[reflect.Type]bool)
+	}
+	if g.marshalerWarnings[t] {
+		return
+	}
+	g.marshalerWarnings[t] = true
+	log.Println("gofiber-swagger:", t.String(), "implements json.Marshaler, documenting it as any value. Implement ISwaggerSchema or use RegisterTypeSchema to describe it.")
+}
+
+// implementsInterface reports whether the (non-pointer) type or a pointer to it implements the interface.
+func implementsInterface(t reflect.Type, iface reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	tKind := t.Kind()
+	if tKind == reflect.Interface || tKind == reflect.Pointer || tKind == reflect.UnsafePointer || tKind == reflect.Invalid {
+		return false
+	}
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -0,0 +1,282 @@
+package gofiberswagger
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+/// ------------------------------------------------------------------------------------------------ ///
+/// Go doc comments of handlers and types, used for operation summaries and schema / property docs. ///
+/// ------------------------------------------------------------------------------------------------ ///
+
+// DocComments is an index of the doc comments inside of a module, keyed the same way the runtime names things:
+// types as "import/path.Type", fields as "import/path.Type.Field" and functions as "import/path.Func",
+// "import/path.(*Type).Method" or "import/path.Type.Method". Packages named main are keyed as "main".
+// It can be created from source using ParseDocComments, or loaded from a file created by `gofiberswagger-comments`,
+// so the source doesn't have to be available at runtime.
+type DocComments struct {
+	Types     map[string]string `json:"types"`
+	Fields    map[string]string `json:"fields"`
+	Functions map[string]string `json:"functions"`
+}
+
+// SetDocComments sets the doc comments used by the default generator (used by the top-level functions).
+// Call it before creating any routes / schemas (see Config.SchemaNamingStrategy).
+func SetDocComments(comments *DocComments) {
+	defaultGenerator.SetDocComments(comments)
+}
+
+func (g *Generator) SetDocComments(comments *DocComments) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	g.config.DocComments = comments
+}
+
+// ParseDocComments parses every (non-test) go file inside of the module directory using go/parser.
+// The import paths are resolved using the go.mod inside of the directory (or the closest one above it).
+func ParseDocComments(dir string) (*DocComments, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	module_dir, module_path, err := findModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := &DocComments{Types: map[string]string{}, Fields: map[string]string{}, Functions: map[string]string{}}
+	file_set := token.NewFileSet()
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(file_set, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		relative_dir, err := filepath.Rel(module_dir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		package_path := module_path
+		if relative_dir != "." {
+			package_path += "/" + filepath.ToSlash(relative_dir)
+		}
+		if file.Name.Name == "main" {
+			package_path = "main"
+		}
+		comments.addFile(package_path, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// LoadDocComments loads an index created by `gofiberswagger-comments` (or DocComments.WriteFile).
+func LoadDocComments(path string) (*DocComments, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	comments := &DocComments{}
+	if err := json.Unmarshal(data, comments); err != nil {
+		return nil, errors.Join(errors.New("gofiber-swagger: error while parsing the doc comments index -> "), err)
+	}
+	return comments, nil
+}
+
+func (comments *DocComments) WriteFile(path string) error {
+	data, err := json.MarshalIndent(comments, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func findModule(dir string) (module_dir string, module_path string, err error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		data, err := os.ReadFile(filepath.Join(current, "go.mod"))
+		if err == nil {
+			module_path := goModModulePath(data)
+			if module_path == "" {
+				return "", "", errors.New("gofiber-swagger: unable to read the module path from " + filepath.Join(current, "go.mod"))
+			}
+			return current, module_path, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", "", errors.New("gofiber-swagger: unable to find go.mod for " + dir)
+		}
+	}
+}
+
+func goModModulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if module_path, ok := strings.CutPrefix(strings.TrimSpace(line), "module"); ok {
+			return strings.Trim(strings.TrimSpace(module_path), "\"`")
+		}
+	}
+	return ""
+}
+
+func (comments *DocComments) addFile(package_path string, file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if text := commentText(decl.Doc); text != "" {
+				comments.Functions[package_path+"."+funcDeclName(decl)] = text
+			}
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				type_spec := spec.(*ast.TypeSpec)
+				doc := type_spec.Doc
+				if doc == nil && len(decl.Specs) == 1 {
+					doc = decl.Doc
+				}
+				type_key := package_path + "." + type_spec.Name.Name
+				if text := commentText(doc); text != "" {
+					comments.Types[type_key] = text
+				}
+
+				struct_type, ok := type_spec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range struct_type.Fields.List {
+					text := commentText(field.Doc)
+					if text == "" {
+						text = commentText(field.Comment)
+					}
+					if text == "" {
+						continue
+					}
+					for _, name := range field.Names {
+						comments.Fields[type_key+"."+name.Name] = text
+					}
+					if len(field.Names) == 0 {
+						comments.Fields[type_key+"."+embeddedFieldName(field.Type)] = text
+					}
+				}
+			}
+		}
+	}
+}
+
+// funcDeclName returns the name of the function the same way runtime.FuncForPC does, eg. "(*Server).GetUser"
+func funcDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	receiver := decl.Recv.List[0].Type
+	pointer := false
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver, pointer = star.X, true
+	}
+	receiver_name := embeddedFieldName(receiver)
+	if pointer {
+		return "(*" + receiver_name + ")." + decl.Name.Name
+	}
+	return receiver_name + "." + decl.Name.Name
+}
+
+func embeddedFieldName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return embeddedFieldName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedFieldName(expr.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(expr.X)
+	}
+	return ""
+}
+
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.TrimSpace(group.Text())
+}
+
+func (comments *DocComments) typeDoc(t reflect.Type) string {
+	if comments == nil || t.Name() == "" {
+		return ""
+	}
+	return comments.Types[docCommentsTypeKey(t)]
+}
+
+func (comments *DocComments) fieldDoc(t reflect.Type, field reflect.StructField) string {
+	if comments == nil || t == nil || t.Name() == "" {
+		return ""
+	}
+	return comments.Fields[docCommentsTypeKey(t)+"."+field.Name]
+}
+
+func (comments *DocComments) handlerDoc(handler fiber.Handler) string {
+	if comments == nil || handler == nil {
+		return ""
+	}
+	function := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if function == nil {
+		return ""
+	}
+	return comments.Functions[strings.TrimSuffix(function.Name(), "-fm")]
+}
+
+func docCommentsTypeKey(t reflect.Type) string {
+	name := t.Name()
+	if bracket := strings.IndexByte(name, '['); bracket != -1 {
+		name = name[:bracket]
+	}
+	return t.PkgPath() + "." + name
+}
+
+// splitDocComment splits the comment into the summary (first sentence) and the description (whole comment, if longer than the summary).
+func splitDocComment(text string) (summary string, description string) {
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+	paragraph = strings.Join(strings.Fields(paragraph), " ")
+	summary = paragraph
+	if end := strings.Index(paragraph, ". "); end != -1 {
+		summary = paragraph[:end+1]
+	}
+	if summary != text {
+		description = text
+	}
+	return summary, description
+}
+
+func (g *Generator) docComments() *DocComments {
+	g.schemasMutex.RLock()
+	defer g.schemasMutex.RUnlock()
+	return g.config.DocComments
+}
@@ -0,0 +1,110 @@
+package gofiberswagger
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDocComments(t *testing.T) {
+	t.Parallel()
+
+	comments, err := ParseDocComments("./testdata/doccomments")
+	assert.NoError(t, err)
+
+	pkg := "github.com/TDiblik/gofiber-swagger/gofiberswagger/testdata/doccomments"
+	assert.Equal(t, "User is a registered user.", comments.Types[pkg+".User"])
+	assert.Equal(t, "Meta holds metadata shared by multiple types.", comments.Types[pkg+".Meta"])
+	assert.Equal(t, "Unique identifier of the user.", comments.Fields[pkg+".User.Id"])
+	assert.Equal(t, "Display name, shown in the UI.", comments.Fields[pkg+".User.Name"])
+	assert.NotContains(t, comments.Types, pkg+".Server")
+	assert.Equal(t, "GetUser returns a single user. The user has to exist.\n\nResponds with 404 otherwise.", comments.Functions[pkg+".GetUser"])
+	assert.Equal(t, "ListUsers lists all users.", comments.Functions[pkg+".(*Server).ListUsers"])
+	assert.Equal(t, "Health reports whether the server is up.", comments.Functions[pkg+".Server.Health"])
+
+	path := filepath.Join(t.TempDir(), "comments.json")
+	assert.NoError(t, comments.WriteFile(path))
+	loaded, err := LoadDocComments(path)
+	assert.NoError(t, err)
+	assert.Equal(t, comments, loaded)
+}
+
+func TestSplitDocComment(t *testing.T) {
+	t.Parallel()
+
+	summary, description := splitDocComment("GetUser returns a single user. The user has to exist.\n\nResponds with 404 otherwise.")
+	assert.Equal(t, "GetUser returns a single user.", summary)
+	assert.Equal(t, "GetUser returns a single user. The user has to exist.\n\nResponds with 404 otherwise.", description)
+
+	summary, description = splitDocComment("ListUsers lists all users.")
+	assert.Equal(t, "ListUsers lists all users.", summary)
+	assert.Empty(t, description)
+
+	summary, description = splitDocComment("")
+	assert.Empty(t, summary)
+	assert.Empty(t, description)
+}
+
+type DocCommentsRequest struct {
+	Name string `json:"name"`
+}
+
+// DocCommentsHandler creates something.
+func DocCommentsHandler(c fiber.Ctx) error {
+	return c.SendStatus(200)
+}
+
+func TestRegister_DocComments(t *testing.T) {
+	t.Parallel()
+
+	pkg := reflect.TypeFor[DocCommentsRequest]().PkgPath()
+	comments := &DocComments{
+		Types:     map[string]string{pkg + ".DocCommentsRequest": "Request of the handler."},
+		Fields:    map[string]string{pkg + ".DocCommentsRequest.Name": "Name of the thing."},
+		Functions: map[string]string{pkg + ".DocCommentsHandler": "DocCommentsHandler creates something."},
+	}
+
+	app := fiber.New()
+	generator := NewGenerator(Config{DocComments: comments})
+	router := generator.NewRouter(app)
+	router.Post("/documented", nil, DocCommentsHandler)
+	router.Post("/manual", &RouteInfo{Summary: "manual"}, DocCommentsHandler)
+
+	config := Config{}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	assert.NoError(t, generator.register(app, config))
+
+	documented := config.Swagger.Paths.Find("/documented").Post
+	assert.Equal(t, "DocCommentsHandler creates something.", documented.Summary)
+	assert.Empty(t, documented.Description)
+	assert.Equal(t, "manual", config.Swagger.Paths.Find("/manual").Post.Summary)
+
+	schema := generator.CreateSchema(reflect.TypeFor[DocCommentsRequest]())
+	assert.Equal(t, "Request of the handler.", schema.Value.Description)
+	assert.Equal(t, "Name of the thing.", schema.Value.Properties["name"].Value.Description)
+}
+
+func TestRegister_DocCommentsOfTheRegisterConfig(t *testing.T) {
+	t.Parallel()
+
+	pkg := reflect.TypeFor[DocCommentsRequest]().PkgPath()
+	comments := &DocComments{Types: map[string]string{pkg + ".DocCommentsRequest": "Request of the handler."}}
+	register := func(generator *Generator) error {
+		config := Config{DocComments: comments}
+		config.Swagger = swaggerConfigDefault(config.Swagger)
+		return generator.register(fiber.New(), config)
+	}
+
+	// no schemas yet, the doc comments get applied
+	generator := NewGenerator(Config{})
+	assert.NoError(t, register(generator))
+	assert.Equal(t, "Request of the handler.", generator.CreateSchema(reflect.TypeFor[DocCommentsRequest]()).Value.Description)
+
+	// the schemas were already generated without them
+	generator = NewGenerator(Config{})
+	generator.CreateSchema(reflect.TypeFor[DocCommentsRequest]())
+	assert.ErrorContains(t, register(generator), "DocComments of the config differ")
+}
@@ -0,0 +1,100 @@
+package gofiberswagger
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+/// ------------------------------------------------------------- ///
+/// Protecting the swagger routes (UI, json and yaml) of Register ///
+/// ------------------------------------------------------------- ///
+
+const (
+	// Header the shared secret (Config.SharedSecret) can be sent in, eg. by scripts downloading the spec.
+	SharedSecretHeader = "X-Swagger-Secret"
+	// Query parameter the shared secret can be sent in, eg. /swagger?secret=... when opening the UI in a browser.
+	SharedSecretQuery = "secret"
+	// Cookie the shared secret gets remembered in after opening the UI, so it can load the spec.
+	sharedSecretCookie = "gofiberswagger_secret"
+)
+
+// docsHandlers returns the handlers running before every swagger route: the middlewares of the config first, then the built-in auth.
+func docsHandlers(config Config) []any {
+	handlers := append([]any{}, config.Middlewares...)
+	if config.SharedSecret != "" {
+		handlers = append(handlers, sharedSecretHandler(config.SharedSecret, config.BasePath))
+	}
+	if len(config.BasicAuthUsers) > 0 {
+		handlers = append(handlers, basicAuthHandler(config.BasicAuthUsers))
+	}
+	return handlers
+}
+
+// sharedSecretHandler accepts the secret from the header, the query parameter or the cookie set after it was accepted,
+// since the UI doesn't forward the query parameter when loading the spec.
+func sharedSecretHandler(secret string, base_path string) fiber.Handler {
+	// only a hash of the secret gets stored in the browser
+	hashed_secret := sha256.Sum256([]byte(secret))
+	cookie_value := hex.EncodeToString(hashed_secret[:])
+
+	return func(c fiber.Ctx) error {
+		if secretsEqual(c.Cookies(sharedSecretCookie), cookie_value) || secretsEqual(c.Get(SharedSecretHeader), secret) {
+			return c.Next()
+		}
+		if secretsEqual(c.Query(SharedSecretQuery), secret) {
+			c.Cookie(&fiber.Cookie{
+				Name:     sharedSecretCookie,
+				Value:    cookie_value,
+				Path:     base_path,
+				HTTPOnly: true,
+				Secure:   c.Protocol() == "https",
+				SameSite: fiber.CookieSameSiteStrictMode,
+			})
+			return c.Next()
+		}
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+}
+
+// basicAuthHandler requires the credentials of one of the users (username -> password),
+// the browser asks for them when opening the UI and keeps sending them when loading the spec.
+func basicAuthHandler(users map[string]string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		username, password, ok := parseBasicAuth(c.Get(fiber.HeaderAuthorization))
+		if ok {
+			// every user gets compared, so the time taken doesn't tell which usernames exist
+			authorized := false
+			for expected_username, expected_password := range users {
+				if secretsEqual(username, expected_username) && secretsEqual(password, expected_password) {
+					authorized = true
+				}
+			}
+			if authorized {
+				return c.Next()
+			}
+		}
+		c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="Swagger", charset="UTF-8"`)
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+}
+
+func parseBasicAuth(header string) (string, string, bool) {
+	scheme, encoded, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "basic") {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+func secretsEqual(given string, expected string) bool {
+	return given != "" && subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
@@ -0,0 +1,98 @@
+package gofiberswagger
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func docsStatus(t *testing.T, app *fiber.App, target string, headers map[string]string) int {
+	request := httptest.NewRequest("GET", target, nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	resp, err := app.Test(request)
+	assert.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestDocsAuth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should run the middlewares before every swagger route", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		app.Get("/public", func(c fiber.Ctx) error { return c.SendString("public") })
+		allowlist := func(c fiber.Ctx) error {
+			if c.Get("X-Forwarded-For") != "10.0.0.1" {
+				return c.SendStatus(fiber.StatusForbidden)
+			}
+			return c.Next()
+		}
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{BasePath: "/", Middlewares: []any{allowlist}}))
+
+		for _, target := range []string{"/", "/index.html", "/swagger-initializer.js", "/swagger.json", "/swagger.yaml"} {
+			assert.Equal(t, fiber.StatusForbidden, docsStatus(t, app, target, nil), target)
+			assert.Equal(t, fiber.StatusOK, docsStatus(t, app, target, map[string]string{"X-Forwarded-For": "10.0.0.1"}), target)
+		}
+		// mounted at "/", yet the rest of the app stays public
+		assert.Equal(t, fiber.StatusOK, docsStatus(t, app, "/public", nil))
+	})
+
+	t.Run("should require the shared secret", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{SharedSecret: "s3cret"}))
+
+		assert.Equal(t, fiber.StatusUnauthorized, docsStatus(t, app, "/swagger/swagger.json", nil))
+		assert.Equal(t, fiber.StatusUnauthorized, docsStatus(t, app, "/swagger/swagger.json?secret=wrong", nil))
+		assert.Equal(t, fiber.StatusOK, docsStatus(t, app, "/swagger/swagger.json", map[string]string{SharedSecretHeader: "s3cret"}))
+
+		// opening the UI using the query parameter lets it load the spec without it
+		resp, err := app.Test(httptest.NewRequest("GET", "/swagger?secret=s3cret", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		cookies := resp.Cookies()
+		assert.Len(t, cookies, 1)
+		assert.Equal(t, "/swagger", cookies[0].Path)
+		assert.NotContains(t, cookies[0].Value, "s3cret")
+
+		request := httptest.NewRequest("GET", "/swagger/swagger.yaml", nil)
+		request.AddCookie(cookies[0])
+		resp, err = app.Test(request)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should require basic auth credentials", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{BasicAuthUsers: map[string]string{"admin": "pass", "viewer": "view"}}))
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/swagger", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderWWWAuthenticate), "Basic")
+
+		for _, credentials := range [][2]string{{"admin", "view"}, {"nobody", "pass"}, {"admin", ""}} {
+			request := httptest.NewRequest("GET", "/swagger/swagger.json", nil)
+			request.SetBasicAuth(credentials[0], credentials[1])
+			resp, err := app.Test(request)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, credentials)
+		}
+		for _, credentials := range [][2]string{{"admin", "pass"}, {"viewer", "view"}} {
+			request := httptest.NewRequest("GET", "/swagger/swagger.json", nil)
+			request.SetBasicAuth(credentials[0], credentials[1])
+			resp, err := app.Test(request)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode, credentials)
+		}
+		assert.Equal(t, fiber.StatusUnauthorized, docsStatus(t, app, "/swagger", map[string]string{fiber.HeaderAuthorization: "Basic not-base64"}))
+	})
+}
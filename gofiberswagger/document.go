@@ -0,0 +1,136 @@
+package gofiberswagger
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gofiber/fiber/v3"
+)
+
+// documentSnapshot holds the document produced by the last Register call, so the validators can look up operations at runtime.
+// The document is re-loaded from its json form, that way the validators work with exactly what gets served
+// and with plain json values (eg. enum values are no longer typed Go constants).
+type documentSnapshot struct {
+	schemaAsJson []byte
+	routes       []documentedRoute
+
+	loadOnce sync.Once
+	document *openapi3.T
+	loadErr  error
+}
+
+type documentedRoute struct {
+	method  string
+	path    string
+	params  []string
+	matcher *regexp.Regexp
+}
+
+var pathParameterRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+func newDocumentedRoute(method string, path string, app_config fiber.Config) documentedRoute {
+	pattern := strings.Builder{}
+	if !app_config.CaseSensitive {
+		pattern.WriteString("(?i)")
+	}
+	pattern.WriteString("^")
+
+	params := []string{}
+	last_index := 0
+	for _, match := range pathParameterRegex.FindAllStringSubmatchIndex(path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(path[last_index:match[0]]))
+		param_name := path[match[2]:match[3]]
+		switch param_name[0] {
+		case '*':
+			pattern.WriteString("(.*)")
+		case '+':
+			pattern.WriteString("(.+)")
+		default:
+			pattern.WriteString("([^/]+)")
+		}
+		params = append(params, param_name)
+		last_index = match[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(strings.TrimSuffix(path[last_index:], "/")))
+	if !app_config.StrictRouting {
+		pattern.WriteString("/?")
+	} else if strings.HasSuffix(path, "/") {
+		pattern.WriteString("/")
+	}
+	pattern.WriteString("$")
+
+	return documentedRoute{
+		method:  strings.ToUpper(method),
+		path:    path,
+		params:  params,
+		matcher: regexp.MustCompile(pattern.String()),
+	}
+}
+
+func (g *Generator) setDocumentSnapshot(schema_as_json []byte, routes []documentedRoute) {
+	g.documentMutex.Lock()
+	defer g.documentMutex.Unlock()
+	g.document = &documentSnapshot{schemaAsJson: schema_as_json, routes: routes}
+}
+
+func (g *Generator) getDocumentSnapshot() *documentSnapshot {
+	g.documentMutex.RLock()
+	defer g.documentMutex.RUnlock()
+	return g.document
+}
+
+func (snapshot *documentSnapshot) load() (*openapi3.T, error) {
+	snapshot.loadOnce.Do(func() {
+		loader := openapi3.NewLoader()
+		snapshot.document, snapshot.loadErr = loader.LoadFromData(snapshot.schemaAsJson)
+		if snapshot.loadErr != nil {
+			snapshot.loadErr = errors.Join(errors.New("gofiber-swagger: unable to load the generated document -> "), snapshot.loadErr)
+		}
+	})
+	return snapshot.document, snapshot.loadErr
+}
+
+// findRoute returns the documented operation matching the request method and path, or nil if the route isn't documented.
+func (snapshot *documentSnapshot) findRoute(method string, path string) (*routers.Route, map[string]string, error) {
+	document, err := snapshot.load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	method = strings.ToUpper(method)
+	for _, route := range snapshot.routes {
+		if route.method != method {
+			continue
+		}
+		matches := route.matcher.FindStringSubmatch(path)
+		if matches == nil {
+			continue
+		}
+
+		path_item := document.Paths.Find(route.path)
+		if path_item == nil {
+			continue
+		}
+		operation := path_item.GetOperation(method)
+		if operation == nil {
+			continue
+		}
+
+		path_params := make(map[string]string, len(route.params))
+		for i, param_name := range route.params {
+			path_params[param_name] = matches[i+1]
+		}
+		return &routers.Route{
+			Spec:      document,
+			Path:      route.path,
+			PathItem:  path_item,
+			Method:    method,
+			Operation: operation,
+		}, path_params, nil
+	}
+	return nil, nil, nil
+}
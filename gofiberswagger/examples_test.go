@@ -0,0 +1,151 @@
+package gofiberswagger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type ExamplesOrder struct {
+	Id        uuid.UUID        `json:"id" swagger:"readonly"`
+	Email     string           `json:"email" validate:"email"`
+	Name      string           `json:"name" example:"John"`
+	Status    string           `json:"status" validate:"oneof=pending paid"`
+	Quantity  int              `json:"quantity" validate:"gt=10,lte=20"`
+	Price     float64          `json:"price" validate:"gte=0.5"`
+	Code      string           `json:"code" validate:"min=10"`
+	Tags      []string         `json:"tags" validate:"min=2"`
+	Counts    map[int]int      `json:"counts"`
+	CreatedAt time.Time        `json:"created_at"`
+	Parent    *ExamplesOrder   `json:"parent"`
+	Password  string           `json:"password" swagger:"writeonly"`
+	Extra     *ExamplesAddress `json:"extra"`
+	Phone     string           `json:"phone" validate:"e164"`
+	Postcode  string           `json:"postcode" pattern:"^[0-9]{3} [0-9]{2}$"`
+}
+
+type ExamplesAddress struct {
+	Street string `json:"street" example:"Main Street"`
+}
+
+type ExamplesProvided struct {
+	Name string `json:"name"`
+}
+
+func (*ExamplesProvided) Examples() []ExamplesProvided {
+	return []ExamplesProvided{{Name: "first"}, {Name: "second"}}
+}
+
+type ExamplesPointer struct {
+	Name string `json:"name"`
+}
+
+func (e *ExamplesPointer) Examples() []*ExamplesPointer {
+	return []*ExamplesPointer{{Name: e.Name + "pointer"}}
+}
+
+func generateExamplesDocument(t *testing.T, version OpenAPIVersion) map[string]any {
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+	router.Post("/orders", &RouteInfo{
+		RequestBody: NewRequestBodyJSON[ExamplesOrder](),
+		Responses:   NewResponses(NewResponseInfo[ExamplesOrder]("200", "OK")),
+	}, func(c fiber.Ctx) error { return nil })
+	router.Post("/provided", &RouteInfo{
+		RequestBody: NewRequestBodyJSON[ExamplesProvided](),
+		Responses:   NewResponses(NewResponseInfo[ExamplesProvided]("200", "OK")),
+	}, func(c fiber.Ctx) error { return nil })
+
+	// the examples get validated against their schemas
+	_, as_map := generateTestDocument(t, app, generator, Config{GenerateExamples: true, OpenAPIVersion: version})
+	return as_map["paths"].(map[string]any)
+}
+
+func mediaTypeOf(paths map[string]any, path string, response bool) map[string]any {
+	operation := paths[path].(map[string]any)["post"].(map[string]any)
+	if response {
+		return operation["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+	}
+	return operation["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+}
+
+func TestGenerateExamples(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []OpenAPIVersion{OpenAPIVersion30, OpenAPIVersion31} {
+		t.Run(string(version), func(t *testing.T) {
+			t.Parallel()
+
+			paths := generateExamplesDocument(t, version)
+
+			request := mediaTypeOf(paths, "/orders", false)["example"].(map[string]any)
+			assert.NotContains(t, request, "id")
+			assert.Equal(t, "user@example.com", request["email"])
+			assert.Equal(t, "John", request["name"])
+			assert.Equal(t, "pending", request["status"])
+			assert.Equal(t, float64(11), request["quantity"])
+			assert.Equal(t, 1.5, request["price"])
+			assert.Equal(t, "stringxxxx", request["code"])
+			assert.Len(t, request["tags"], 2)
+			assert.Equal(t, map[string]any{"1": float64(1)}, request["counts"])
+			assert.Equal(t, "2024-01-01T12:00:00Z", request["created_at"])
+			assert.NotContains(t, request, "parent")
+			assert.Equal(t, "string", request["password"])
+			assert.Equal(t, map[string]any{"street": "Main Street"}, request["extra"])
+			assert.Equal(t, "+420123456789", request["phone"])
+			// no example matching an unknown pattern, the example would be invalid
+			assert.Nil(t, mediaTypeOf(paths, "/orders", true)["example"].(map[string]any)["postcode"])
+
+			response := mediaTypeOf(paths, "/orders", true)["example"].(map[string]any)
+			assert.Equal(t, "3fa85f64-5717-4562-b3fc-2c963f66afa6", response["id"])
+			assert.NotContains(t, response, "password")
+
+			provided := mediaTypeOf(paths, "/provided", false)
+			assert.Nil(t, provided["example"])
+			assert.Equal(t, map[string]any{"value": map[string]any{"name": "second"}}, provided["examples"].(map[string]any)["example2"])
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		generator := NewGenerator(Config{})
+		generator.NewRouter(app).Post("/orders", &RouteInfo{
+			RequestBody: NewRequestBodyJSON[ExamplesAddress](),
+			Responses:   NewResponses(NewResponseInfo[ExamplesAddress]("200", "OK")),
+		}, func(c fiber.Ctx) error { return nil })
+		config := Config{}
+		config.Swagger = swaggerConfigDefault(DefaultSwaggerConfig)
+		assert.NoError(t, generator.register(app, config))
+
+		as_map := map[string]any{}
+		assert.NoError(t, json.Unmarshal(generator.getDocumentSnapshot().schemaAsJson, &as_map))
+		assert.Nil(t, mediaTypeOf(as_map["paths"].(map[string]any), "/orders", false)["example"])
+	})
+}
+
+func TestTypeExamples_PointerType(t *testing.T) {
+	t.Parallel()
+
+	// the pointer receiver doesn't get called on nil
+	examples := typeExamples[*ExamplesPointer]()
+	assert.Len(t, examples, 1)
+	assert.Equal(t, &ExamplesPointer{Name: "pointer"}, examples["example1"].Value.Value)
+}
+
+func TestValidationTagPatternExamples(t *testing.T) {
+	t.Parallel()
+
+	for tag, pattern := range validationTagPatterns {
+		example, ok := validationTagPatternExamples[tag]
+		if assert.True(t, ok, tag) {
+			assert.Regexp(t, pattern, example, tag)
+		}
+	}
+}
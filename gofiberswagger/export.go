@@ -0,0 +1,72 @@
+package gofiberswagger
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+/// ------------------------------------------------------------------------------------ ///
+/// Exporting the document without mounting the swagger routes or starting the server ///
+/// ------------------------------------------------------------------------------------ ///
+
+// Export builds the same document Register would serve and writes it as json into w, eg. to commit the spec or to check it in CI.
+// Only the routes of the app are needed, the swagger routes don't get mounted and the server doesn't have to be started.
+// It's used by the default generator (used by the top-level functions).
+func Export(app *fiber.App, config Config, w io.Writer) error {
+	return defaultGenerator.export(app, config, w, false)
+}
+
+// ExportYAML is the same as Export, but writes the document as yaml.
+func ExportYAML(app *fiber.App, config Config, w io.Writer) error {
+	return defaultGenerator.export(app, config, w, true)
+}
+
+// ExportFiles writes the files created by Config.CreateSwaggerFiles (index.html, swagger-initializer.js, swagger.json and swagger.yaml) into the directory,
+// without mounting the swagger routes or starting the server.
+func ExportFiles(app *fiber.App, config Config, dir string) error {
+	return defaultGenerator.exportFiles(app, config, dir)
+}
+
+// Export writes the document of the app as json into w, using the config of the Generator (see the top-level Export).
+func (g *Generator) Export(app *fiber.App, w io.Writer) error {
+	return g.export(app, g.config, w, false)
+}
+
+// ExportYAML writes the document of the app as yaml into w, using the config of the Generator.
+func (g *Generator) ExportYAML(app *fiber.App, w io.Writer) error {
+	return g.export(app, g.config, w, true)
+}
+
+// ExportFiles writes index.html, swagger-initializer.js, swagger.json and swagger.yaml into the directory, using the config of the Generator.
+func (g *Generator) ExportFiles(app *fiber.App, dir string) error {
+	return g.exportFiles(app, g.config, dir)
+}
+
+func (g *Generator) export(app *fiber.App, config Config, w io.Writer, as_yaml bool) error {
+	built, err := g.buildDocument(app, config)
+	if err != nil {
+		return err
+	}
+
+	output := built.schemaAsJson
+	if as_yaml {
+		output = built.schemaAsYaml
+	}
+	if _, err := w.Write(output); err != nil {
+		return errors.Join(errors.New("gofiber-swagger: error while writing the exported document -> "), err)
+	}
+	return nil
+}
+
+func (g *Generator) exportFiles(app *fiber.App, config Config, dir string) error {
+	if dir == "" {
+		return errors.New("gofiber-swagger: unable to export the swagger files, the directory was left empty")
+	}
+	built, err := g.buildDocument(app, config)
+	if err != nil {
+		return err
+	}
+	return createSwaggerFiles(dir, built.indexPage, built.initializer, built.schemaAsJson, built.schemaAsYaml, built.config.JSONFileName, built.config.YAMLFileName)
+}
@@ -0,0 +1,81 @@
+package gofiberswagger
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func newExportApp() (*fiber.App, *Generator) {
+	app := fiber.New()
+	generator := NewGenerator(Config{AppendMethodToTags: true})
+	generator.NewRouter(app).Get("/users/:id", &RouteInfo{
+		Tags:      []string{"users"},
+		Responses: NewResponses(NewResponseInfo[ExamplesAddress]("200", "OK")),
+	}, func(c fiber.Ctx) error { return nil })
+	return app, generator
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the document without mounting the swagger routes", func(t *testing.T) {
+		t.Parallel()
+
+		app, generator := newExportApp()
+		exported := &bytes.Buffer{}
+		assert.NoError(t, generator.Export(app, exported))
+		assert.Contains(t, exported.String(), `"/users/{id}"`)
+		for _, route := range app.GetRoutes(true) {
+			assert.False(t, strings.HasPrefix(route.Path, "/swagger"), route.Path)
+		}
+
+		// exporting again (or registering afterwards) produces the same document
+		again := &bytes.Buffer{}
+		assert.NoError(t, generator.Export(app, again))
+		assert.Equal(t, exported.String(), again.String())
+
+		assert.NoError(t, generator.Register(app))
+		resp, err := app.Test(httptest.NewRequest("GET", "/swagger/swagger.json", nil))
+		assert.NoError(t, err)
+		served, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, exported.String(), string(served))
+	})
+
+	t.Run("should write yaml", func(t *testing.T) {
+		t.Parallel()
+
+		app, generator := newExportApp()
+		exported := &bytes.Buffer{}
+		assert.NoError(t, generator.ExportYAML(app, exported))
+
+		as_map := map[string]any{}
+		assert.NoError(t, yaml.Unmarshal(exported.Bytes(), &as_map))
+		assert.Contains(t, as_map["paths"], "/users/{id}")
+	})
+
+	t.Run("should write the swagger files", func(t *testing.T) {
+		t.Parallel()
+
+		app, generator := newExportApp()
+		temp_dir := t.TempDir()
+		assert.NoError(t, generator.ExportFiles(app, temp_dir))
+		assert.FileExists(t, filepath.Join(temp_dir, "index.html"))
+		assert.FileExists(t, filepath.Join(temp_dir, "swagger.yaml"))
+
+		json_content, err := os.ReadFile(filepath.Join(temp_dir, "swagger.json"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(json_content), `"/users/{id}"`)
+
+		assert.Error(t, generator.ExportFiles(app, ""))
+	})
+}
@@ -0,0 +1,112 @@
+package gofiberswagger
+
+import (
+	"reflect"
+	"strings"
+)
+
+/// ---------------------------------------------------------------------------------------------- ///
+/// Schemas generated ahead of time by cmd/gofiberswagger-gen, used instead of walking the structs ///
+/// ---------------------------------------------------------------------------------------------- ///
+
+// SchemaResolver returns the schema of a struct field the generator couldn't document statically (other structs, maps, enums, ...),
+// the same way a reflected field would be documented. goName is the name of the go field, title the name of the property.
+type SchemaResolver func(t reflect.Type, goName string, title string) *SchemaRef
+
+// GeneratedSchema is the component of a struct generated by cmd/gofiberswagger-gen.
+// Build returns the object schema (properties and required fields), the component name, title and description are set by the Generator.
+type GeneratedSchema struct {
+	Type  reflect.Type
+	Build func(ref SchemaResolver) *Schema
+}
+
+// RegisterGeneratedSchemas registers schemas generated by cmd/gofiberswagger-gen (the generated files call it from init),
+// so the structs don't get walked using reflection. Types without a generated schema still get reflected.
+// It's used by the default generator (used by the top-level functions).
+func RegisterGeneratedSchemas(schemas ...GeneratedSchema) {
+	defaultGenerator.RegisterGeneratedSchemas(schemas...)
+}
+
+// RegisterGeneratedSchemas registers generated schemas (see the top-level RegisterGeneratedSchemas),
+// eg. `generator.RegisterGeneratedSchemas(models.GeneratedSwaggerSchemas...)`.
+func (g *Generator) RegisterGeneratedSchemas(schemas ...GeneratedSchema) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	if g.generatedSchemas == nil {
+		g.generatedSchemas = make(map[reflect.Type]GeneratedSchema)
+	}
+	for _, schema := range schemas {
+		if schema.Type != nil && schema.Build != nil {
+			g.generatedSchemas[schema.Type] = schema
+		}
+	}
+}
+
+func (g *Generator) getGeneratedSchema(t reflect.Type) (GeneratedSchema, bool) {
+	g.schemasMutex.RLock()
+	defer g.schemasMutex.RUnlock()
+	generated, ok := g.generatedSchemas[t]
+	return generated, ok
+}
+
+// applyGeneratedSchema fills the (already cached) component of the struct using its generated schema.
+// Fields documented by go doc comments get their description, unless it was set by a tag.
+func (g *Generator) applyGeneratedSchema(t reflect.Type, schema *Schema, generated GeneratedSchema, ref string) {
+	built := generated.Build(func(fieldType reflect.Type, goName string, title string) *SchemaRef {
+		return g.resolveGeneratedField(fieldType, nestedSchemaName(ref, goName), title)
+	})
+	if built == nil {
+		return
+	}
+
+	for name, property := range built.Properties {
+		if property == nil || property.Value == nil {
+			continue
+		}
+		schema.Properties[name] = property
+	}
+	if built.Required != nil {
+		schema.Required = built.Required
+	}
+
+	if docs := g.docComments(); docs != nil {
+		nameTags := fieldNameTags(t)
+		for i := range t.NumField() {
+			field := t.Field(i)
+			property := schema.Properties[schemaFieldName(field, nameTags)]
+			if property == nil || property.Value.Description != "" {
+				continue
+			}
+			property.Value.Description = docs.fieldDoc(t, field)
+		}
+	}
+
+	// wrapped once the generated schema is built, since it clears nullable of required fields after resolving them
+	for name, property := range schema.Properties {
+		if property.Ref == "" {
+			continue
+		}
+		if component := g.getFromAcquiredSchemas(strings.TrimPrefix(property.Ref, "#/components/schemas/")); component != nil {
+			schema.Properties[name] = wrapRefSiblings(property, component.Value, false)
+		}
+	}
+}
+
+// resolveGeneratedField documents a field the same way generateFieldSchema does, without the tags (the generator handles those).
+func (g *Generator) resolveGeneratedField(t reflect.Type, nameHint string, title string) *SchemaRef {
+	isNullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		isNullable = true
+	}
+
+	result := g.generateSchema(t, false, nameHint)
+	if result == nil {
+		return nil
+	}
+	fieldSchema := *result.Value
+	fieldResult := &SchemaRef{Ref: result.Ref, Value: &fieldSchema}
+	fieldResult.Value.Nullable = isNullable || result.Value.Nullable
+	fieldResult.Value.Title = title
+	return fieldResult
+}
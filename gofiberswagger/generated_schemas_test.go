@@ -0,0 +1,45 @@
+package gofiberswagger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type GeneratedSchemaUser struct {
+	Name    string                  `json:"name"`
+	Profile *GeneratedSchemaProfile `json:"profile"`
+}
+
+type GeneratedSchemaProfile struct {
+	Bio string `json:"bio"`
+}
+
+func TestGeneratedSchemas(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{SchemaNamingStrategy: ShortSchemaNames})
+	generator.RegisterGeneratedSchemas(GeneratedSchema{
+		Type: reflect.TypeFor[GeneratedSchemaUser](),
+		Build: func(ref SchemaResolver) *Schema {
+			schema := &Schema{Type: &Types{"object"}, Properties: Schemas{}, Required: []string{"name"}}
+			schema.Properties["name"] = &SchemaRef{Value: &Schema{Type: &Types{"string"}, Title: "name", Description: "generated"}}
+			schema.Properties["profile"] = ref(reflect.TypeFor[*GeneratedSchemaProfile](), "Profile", "profile")
+			return schema
+		},
+	})
+
+	schema := generator.CreateSchema(reflect.TypeFor[GeneratedSchemaUser]())
+	assert.Equal(t, "#/components/schemas/GeneratedSchemaUser", schema.Ref)
+	assert.Equal(t, "GeneratedSchemaUser", schema.Value.Title)
+	assert.Equal(t, []string{"name"}, schema.Value.Required)
+	assert.Equal(t, "generated", schema.Value.Properties["name"].Value.Description)
+
+	// fields of other types get generated the same way a reflected field would
+	profile := schema.Value.Properties["profile"]
+	assert.Empty(t, profile.Ref)
+	assert.True(t, profile.Value.Nullable)
+	assert.Equal(t, "#/components/schemas/GeneratedSchemaProfile", profile.Value.AllOf[0].Ref)
+	assert.NotNil(t, generator.getFromAcquiredSchemas("GeneratedSchemaProfile"))
+}
@@ -0,0 +1,249 @@
+package gofiberswagger
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Generator owns everything needed to document a single fiber.App: the registered routes,
+// the cache of generated schemas and the config used by Register.
+// Use one Generator per fiber.App when running multiple apps inside one process,
+// so their docs don't bleed into each other. The top-level functions use a default instance.
+type Generator struct {
+	config Config
+
+	routesMutex        sync.Mutex
+	acquiredRoutesInfo map[string]*RouteInfo
+	docsRoutes         map[string]bool
+
+	schemasMutex    sync.RWMutex
+	acquiredSchemas map[string]*SchemaRef
+
+	schemaNames       map[reflect.Type]string
+	schemaNameOwners  map[string]reflect.Type
+	schemaNameErrors  []error
+	oneOfs            map[reflect.Type]*oneOfRegistration
+	typeSchemas       map[reflect.Type]*Schema
+	marshalerWarnings map[reflect.Type]bool
+	generatedSchemas  map[reflect.Type]GeneratedSchema
+
+	helpersMutex           sync.Mutex
+	helperSchemas          map[reflect.Type]*SchemaRef
+	helperSchemaTypes      map[*Schema]reflect.Type
+	helperStructParameters map[reflect.Type]Parameters
+	helperParameterTypes   map[*Parameter]helperParameter
+
+	documentMutex sync.RWMutex
+	document      *documentSnapshot
+}
+
+var defaultGenerator = NewGenerator(DefaultConfig)
+
+func NewGenerator(config Config) *Generator {
+	return &Generator{config: config}
+}
+
+func (g *Generator) NewRouter(app *fiber.App) SwaggerRouter {
+	return g.NewRouterFromRouter(app.Group("/"))
+}
+func (g *Generator) NewRouterFromRouter(r fiber.Router) SwaggerRouter {
+	return SwaggerRouter{internalGroup: "", Router: r, generator: g}
+}
+
+func (g *Generator) CreateSchema(t reflect.Type) *SchemaRef {
+	if t == nil {
+		return &SchemaRef{Value: &Schema{}}
+	}
+	if schema := g.generateSchema(t, false, ""); schema != nil {
+		return schema
+	}
+	return &SchemaRef{Value: &Schema{}}
+}
+
+func (g *Generator) Register(app *fiber.App) error {
+	return g.register(app, g.config)
+}
+
+// applySchemaConfig applies the naming strategy and doc comments of the config passed to Register / Export (see Config.SchemaNamingStrategy).
+// Left nil, they don't override the ones of the Generator, the package-qualified names are only its fallback (see schemaNamingStrategy).
+func (g *Generator) applySchemaConfig(config Config) error {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	generated := len(g.schemaNames) > 0 || len(g.acquiredSchemas) > 0
+
+	if config.SchemaNamingStrategy != nil {
+		if reflect.ValueOf(config.SchemaNamingStrategy).Pointer() != reflect.ValueOf(g.schemaNamingStrategy()).Pointer() {
+			if generated {
+				return errors.New("gofiber-swagger: the SchemaNamingStrategy of the config differs from the one the schemas were already generated with, set it using SetSchemaNamingStrategy (or NewGenerator) before creating the routes")
+			}
+			g.config.SchemaNamingStrategy = config.SchemaNamingStrategy
+		}
+	}
+	if config.DocComments != nil && config.DocComments != g.config.DocComments {
+		if generated {
+			return errors.New("gofiber-swagger: the DocComments of the config differ from the ones the schemas were already generated with, set them using SetDocComments (or NewGenerator) before creating the routes")
+		}
+		g.config.DocComments = config.DocComments
+	}
+	return nil
+}
+
+func (g *Generator) setToAcquiredSchemas(ref string, schema *SchemaRef) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	if g.acquiredSchemas == nil {
+		g.acquiredSchemas = make(map[string]*SchemaRef)
+	}
+	if schema != nil {
+		g.acquiredSchemas[ref] = schema
+	}
+}
+
+func (g *Generator) getFromAcquiredSchemas(ref string) *SchemaRef {
+	g.schemasMutex.RLock()
+	defer g.schemasMutex.RUnlock()
+	if g.acquiredSchemas == nil {
+		return nil
+	}
+	return g.acquiredSchemas[ref]
+}
+
+/// --------------------------------------------------------------------- ///
+/// Schemas of the route helpers, resolved by the Generator of the router ///
+/// --------------------------------------------------------------------- ///
+
+// The helpers (NewRequestBody[T], NewResponseInfo[T], INewQueryParameter[T], NewParametersFromStruct[T], ...) don't know
+// which Generator the route is going to be registered with, so they use the schemas of the default generator, which remembers
+// the type each of them was generated from (once per type, the helpers share them). A different Generator registering the route
+// regenerates them (see resolveRouteSchemas), so they follow its naming strategy, oneOfs, type schemas and doc comments
+// and end up in its cache, instead of the one of the default generator.
+type helperParameter struct {
+	t     reflect.Type
+	index int
+}
+
+// typedSchema returns the schema of T used by the helpers.
+func typedSchema[T any]() *SchemaRef {
+	return defaultGenerator.helperSchema(reflect.TypeFor[T]())
+}
+
+func (g *Generator) helperSchema(t reflect.Type) *SchemaRef {
+	g.helpersMutex.Lock()
+	defer g.helpersMutex.Unlock()
+	if g.helperSchemas == nil {
+		g.helperSchemas = make(map[reflect.Type]*SchemaRef)
+		g.helperSchemaTypes = make(map[*Schema]reflect.Type)
+	}
+	schema := g.helperSchemas[t]
+	if schema == nil {
+		schema = g.CreateSchema(t)
+		g.helperSchemas[t] = schema
+		g.helperSchemaTypes[schema.Value] = t
+	}
+	return &SchemaRef{Ref: schema.Ref, Value: schema.Value}
+}
+
+// helperParameters returns the parameters of the struct type used by NewParametersFromStruct[T].
+func (g *Generator) helperParameters(t reflect.Type) Parameters {
+	g.helpersMutex.Lock()
+	defer g.helpersMutex.Unlock()
+	if g.helperStructParameters == nil {
+		g.helperStructParameters = make(map[reflect.Type]Parameters)
+		g.helperParameterTypes = make(map[*Parameter]helperParameter)
+	}
+	parameters, ok := g.helperStructParameters[t]
+	if !ok {
+		parameters = g.CreateParametersFromStruct(t)
+		g.helperStructParameters[t] = parameters
+		for i, parameter := range parameters {
+			g.helperParameterTypes[parameter.Value] = helperParameter{t: t, index: i}
+		}
+	}
+	result := Parameters{}
+	for _, parameter := range parameters {
+		result = append(result, &ParameterRef{Value: parameter.Value})
+	}
+	return result
+}
+
+func (g *Generator) getHelperSchemaType(schema *Schema) (reflect.Type, bool) {
+	g.helpersMutex.Lock()
+	defer g.helpersMutex.Unlock()
+	t, ok := g.helperSchemaTypes[schema]
+	return t, ok
+}
+
+func (g *Generator) getHelperParameter(parameter *Parameter) (helperParameter, bool) {
+	g.helpersMutex.Lock()
+	defer g.helpersMutex.Unlock()
+	source, ok := g.helperParameterTypes[parameter]
+	return source, ok
+}
+
+// resolveRouteSchemas regenerates the schemas and parameters the helpers created using the default generator.
+// The refs returned by the helpers belong to the route, so they get replaced in place.
+func (g *Generator) resolveRouteSchemas(info *RouteInfo) {
+	if g == defaultGenerator {
+		return
+	}
+	structParameters := map[reflect.Type]Parameters{}
+	for _, parameter := range info.Parameters {
+		if parameter == nil || parameter.Value == nil {
+			continue
+		}
+		source, ok := defaultGenerator.getHelperParameter(parameter.Value)
+		if !ok {
+			continue
+		}
+		if _, generated := structParameters[source.t]; !generated {
+			structParameters[source.t] = g.CreateParametersFromStruct(source.t)
+		}
+		if resolved := structParameters[source.t]; source.index < len(resolved) {
+			parameter.Value = resolved[source.index].Value
+		}
+	}
+	for _, schema := range operationSchemaRefs(info) {
+		if schema == nil || schema.Value == nil {
+			continue
+		}
+		if t, ok := defaultGenerator.getHelperSchemaType(schema.Value); ok {
+			*schema = *g.CreateSchema(t)
+		}
+	}
+}
+
+// missingOperationSchemas returns the components referenced by the operation, which weren't generated by this Generator,
+// eg. created using the top-level CreateSchema[T] (the default generator) while registering the route using generator.NewRouter.
+func missingOperationSchemas(components Schemas, operation *RouteInfo) []string {
+	missing := []string{}
+	visited := map[*Schema]bool{}
+	var walk func(schema *SchemaRef)
+	walk = func(schema *SchemaRef) {
+		if schema == nil {
+			return
+		}
+		if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+			// the components of the Generator are complete, only the inline schemas need walking
+			if components[name] == nil && !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return
+		}
+		if schema.Value == nil || visited[schema.Value] {
+			return
+		}
+		visited[schema.Value] = true
+		for _, child := range schemaChildren(schema.Value) {
+			walk(child)
+		}
+	}
+	for _, schema := range operationSchemaRefs(operation) {
+		walk(schema)
+	}
+	return missing
+}
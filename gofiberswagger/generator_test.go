@@ -0,0 +1,168 @@
+package gofiberswagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type GeneratorPublicResponse struct {
+	Name string `json:"name"`
+}
+
+type GeneratorAdminResponse struct {
+	Secret string `json:"secret"`
+}
+
+type GeneratorOwnSchema struct {
+	Value int `json:"value"`
+}
+
+func TestGenerator_IsolatesApps(t *testing.T) {
+	t.Parallel()
+
+	handler := func(c fiber.Ctx) error {
+		return c.SendStatus(200)
+	}
+
+	public_app := fiber.New()
+	public_generator := NewGenerator(Config{})
+	public_router := public_generator.NewRouter(public_app)
+	public_router.Get("/users", &RouteInfo{
+		Summary: "public",
+		Responses: NewResponses(
+			NewResponseInfo[GeneratorPublicResponse]("200", "OK"),
+		),
+	}, handler)
+
+	admin_app := fiber.New()
+	admin_generator := NewGenerator(Config{})
+	admin_router := admin_generator.NewRouter(admin_app)
+	admin_router.Get("/users", &RouteInfo{
+		Summary: "admin",
+		Responses: NewResponses(
+			NewResponseInfo[GeneratorAdminResponse]("200", "OK"),
+		),
+	}, handler)
+	admin_generator.CreateSchema(reflect.TypeOf(GeneratorOwnSchema{}))
+
+	// routes are only visible to the generator they were registered with
+	assert.Equal(t, "public", public_generator.getAcquiredRoutesInfo("GET", "/users").Summary)
+	assert.Equal(t, "admin", admin_generator.getAcquiredRoutesInfo("GET", "/users").Summary)
+	assert.Nil(t, getAcquiredRoutesInfo("GET", "/users"))
+
+	public_config := Config{}
+	public_config.Swagger = swaggerConfigDefault(public_config.Swagger)
+	assert.NoError(t, public_generator.register(public_app, public_config))
+
+	admin_config := Config{}
+	admin_config.Swagger = swaggerConfigDefault(admin_config.Swagger)
+	assert.NoError(t, admin_generator.register(admin_app, admin_config))
+
+	assert.NotSame(t, public_config.Swagger.Paths, admin_config.Swagger.Paths)
+	assert.Equal(t, "public", public_config.Swagger.Paths.Find("/users").Get.Summary)
+	assert.Equal(t, "admin", admin_config.Swagger.Paths.Find("/users").Get.Summary)
+
+	// the top-level helpers get resolved by the generator of the router
+	public_schemas := public_config.Swagger.Components.Schemas
+	admin_schemas := admin_config.Swagger.Components.Schemas
+	assert.Contains(t, public_schemas, "github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorPublicResponse")
+	assert.NotContains(t, public_schemas, "github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorAdminResponse")
+	assert.NotContains(t, public_schemas, "github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorOwnSchema")
+	assert.Contains(t, admin_schemas, "github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorAdminResponse")
+	assert.Contains(t, admin_schemas, "github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorOwnSchema")
+	assert.NotContains(t, admin_schemas, "github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorPublicResponse")
+}
+
+func TestGenerator_Register(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+	group := router.Group("/group")
+	group.Get("/endpoint", &RouteInfo{Summary: "Group endpoint"}, func(c fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	registeredDocs := generator.getAcquiredRoutesInfo("GET", "/group/endpoint")
+	assert.NotNil(t, registeredDocs)
+	assert.Contains(t, registeredDocs.Tags, "/group")
+
+	assert.NoError(t, generator.Register(app))
+	resp, err := app.Test(httptest.NewRequest("GET", "/swagger/swagger.json", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+type GeneratorRecursive struct {
+	Name     string                `json:"name"`
+	Children []*GeneratorRecursive `json:"children"`
+}
+
+func TestGenerator_RecursiveSchema(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{})
+	schema := generator.CreateSchema(reflect.TypeOf(GeneratorRecursive{}))
+	assert.NotNil(t, schema)
+
+	children := schema.Value.Properties["children"]
+	assert.NotNil(t, children)
+	assert.Equal(t, schema.Ref, children.Value.Items.Ref)
+	assert.Same(t, schema.Value, children.Value.Items.Value)
+}
+
+type GeneratorOwner struct {
+	Name string `json:"name"`
+}
+
+type GeneratorUser struct {
+	Owner GeneratorOwner `json:"owner" swagger:"readonly"`
+}
+
+type GeneratorMoney struct {
+	Cents int
+}
+
+type GeneratorQuery struct {
+	MinPrice GeneratorMoney `query:"min_price"`
+}
+
+func TestGenerator_ResolvesHelpersAgainstTheRouter(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{SchemaNamingStrategy: ShortSchemaNames})
+	generator.RegisterTypeSchema(reflect.TypeFor[GeneratorMoney](), &Schema{Type: &Types{"string"}, Pattern: `^\d+\.\d{2}$`})
+	generator.NewRouter(app).Post("/users", &RouteInfo{
+		Parameters:  NewParametersFromStruct[GeneratorQuery](),
+		RequestBody: NewRequestBodyJSON[GeneratorUser](),
+		Responses:   NewResponses(NewResponseInfo[GeneratorUser]("200", "OK")),
+	}, func(c fiber.Ctx) error { return nil })
+
+	config := Config{}
+	config.Swagger = swaggerConfigDefault(DefaultSwaggerConfig)
+	assert.NoError(t, generator.register(app, config))
+	operation := config.Swagger.Paths.Find("/users").Post
+
+	// the naming strategy and type schemas of the generator are used, not the ones of the default generator
+	assert.Equal(t, "#/components/schemas/GeneratorUser", operation.RequestBody.Value.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "string", operation.Parameters[0].Value.Schema.Value.Type.Slice()[0])
+
+	// used outside of a router, the helpers document the schemas of the default generator
+	body := NewRequestBodyJSON[GeneratorUser]().Value.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/github_com_TDiblik_gofiber-swagger_gofiberswaggerGeneratorUser", body.Ref)
+	assert.NotEmpty(t, body.Value.Properties)
+	assert.Equal(t, "object", NewParametersFromStruct[GeneratorQuery]()[0].Value.Schema.Value.Type.Slice()[0])
+
+	// components come from the cache of the generator, not from the (field level) copy of a property
+	owner := config.Swagger.Components.Schemas["GeneratorOwner"]
+	assert.NotNil(t, owner)
+	assert.Equal(t, "GeneratorOwner", owner.Value.Title)
+	assert.False(t, owner.Value.ReadOnly)
+}
@@ -0,0 +1,188 @@
+package gofiberswagger
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+/// ---------------------------------------------------------------------------------------- ///
+/// Resolution of the fields of a struct, following the rules of encoding/json (which is the  ///
+/// default JSONEncoder of fiber): unexported fields, embedded structs and field shadowing.  ///
+/// ---------------------------------------------------------------------------------------- ///
+
+// schemaField is a field documented as a property of a struct, together with the struct which declares it
+// (which differs from the documented struct for fields promoted from embedded structs).
+type schemaField struct {
+	field  reflect.StructField
+	name   string
+	owner  reflect.Type
+	index  []int
+	tagged bool
+}
+
+// schemaFields returns the fields of the struct the same way encoding/json would serialize them:
+//   - unexported fields are skipped, ignored fields (`json:"-"`, `swaggerignore:"true"`, ...) too
+//   - fields of untagged embedded structs (and of fields tagged as `json:",inline"`) get promoted into the struct
+//   - tagged embedded structs (`json:"name"`) become regular properties
+//   - when multiple fields end up with the same name, the least nested one wins. If there are multiple of them,
+//     the tagged one wins, otherwise all of them are dropped (same as encoding/json does)
+//
+// Fields always serialized by encoding/json (the ones without omitempty) aren't added to required, the same schema documents
+// request bodies, where encoding/json leaves the missing fields at their zero value. Only `validate:"required"` makes a field required.
+// A custom JSONEncoder of fiber is expected to follow the same rules, the docs can't see how it serializes the struct.
+func schemaFields(t reflect.Type) []schemaField {
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+
+	nameTags := fieldNameTags(t)
+	candidates := []schemaField{}
+	next := []embedded{{t: t}}
+	visited := map[reflect.Type]bool{}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		// types embedded multiple times at the same depth are walked multiple times, so their fields conflict and get dropped
+		for _, parent := range current {
+			if visited[parent.t] {
+				continue
+			}
+
+			for i := 0; i < parent.t.NumField(); i++ {
+				field := parent.t.Field(i)
+				index := append(slices.Clone(parent.index), i)
+				if field.Name == "XMLName" || isIgnoredField(field, nameTags) {
+					continue
+				}
+
+				fieldType := derefType(field.Type)
+				if field.Anonymous {
+					if !field.IsExported() && fieldType.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !field.IsExported() {
+					continue
+				}
+
+				tagged := hasSchemaFieldName(field, nameTags)
+				if ((field.Anonymous && !tagged) || isInlineField(field)) && fieldType.Kind() == reflect.Struct && !isSpecialStructType(fieldType) {
+					next = append(next, embedded{t: fieldType, index: index})
+					continue
+				}
+				if !field.IsExported() {
+					continue
+				}
+
+				candidates = append(candidates, schemaField{field: field, name: schemaFieldName(field, nameTags), owner: parent.t, index: index, tagged: tagged})
+			}
+		}
+		for _, parent := range current {
+			visited[parent.t] = true
+		}
+	}
+
+	// the least nested field wins, if there are multiple of them, the tagged one wins, otherwise all of them get dropped
+	slices.SortStableFunc(candidates, func(a, b schemaField) int {
+		if a.name != b.name {
+			return strings.Compare(a.name, b.name)
+		}
+		if len(a.index) != len(b.index) {
+			return len(a.index) - len(b.index)
+		}
+		if a.tagged != b.tagged && a.tagged {
+			return -1
+		}
+		if a.tagged != b.tagged {
+			return 1
+		}
+		return 0
+	})
+	fields := []schemaField{}
+	for i := 0; i < len(candidates); {
+		j := i + 1
+		for j < len(candidates) && candidates[j].name == candidates[i].name {
+			j++
+		}
+		dominant := candidates[i]
+		if j-i == 1 || len(candidates[i+1].index) != len(dominant.index) || candidates[i+1].tagged != dominant.tagged {
+			fields = append(fields, dominant)
+		}
+		i = j
+	}
+
+	slices.SortFunc(fields, func(a, b schemaField) int { return slices.Compare(a.index, b.index) })
+	return fields
+}
+
+var (
+	jsonNameTags = []string{"json"}
+	formNameTags = []string{"form", "query", "json"}
+)
+
+// fieldNameTags returns the tags the property names of the struct come from. encoding/json only looks at the json tag,
+// so the form / query tags are used only by the structs without any json names, eg. the ones bound from forms (see /examples/file-upload/).
+func fieldNameTags(t reflect.Type) []string {
+	if usesJSONNames(t, map[reflect.Type]bool{}) {
+		return jsonNameTags
+	}
+	return formNameTags
+}
+
+func usesJSONNames(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if t.Kind() != reflect.Struct || visited[t] {
+		return false
+	}
+	visited[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag, ok := field.Tag.Lookup("json"); ok && tag != "-" {
+			return true
+		}
+		if field.Anonymous && usesJSONNames(derefType(field.Type), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIgnoredField reports whether the field is explicitly excluded from the docs ("-" as its name, see fieldNameTags,
+// xml "-" tags without any name tag or `swaggerignore:"true"`).
+func isIgnoredField(field reflect.StructField, nameTags []string) bool {
+	if field.Tag.Get("swaggerignore") == "true" {
+		return true
+	}
+	for _, tagName := range append(slices.Clone(nameTags), "xml") {
+		if tag, ok := field.Tag.Lookup(tagName); ok {
+			return strings.Split(tag, ",")[0] == "-"
+		}
+	}
+	return false
+}
+
+// hasSchemaFieldName reports whether the name of the field comes from a tag (see schemaFieldName).
+func hasSchemaFieldName(field reflect.StructField, nameTags []string) bool {
+	for _, tagName := range nameTags {
+		if parts := strings.Split(field.Tag.Get(tagName), ","); parts[0] != "" && parts[0] != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// isInlineField reports whether the field is tagged as `json:",inline"` (encoding/json/v2, also commonly used by other encoders).
+func isInlineField(field reflect.StructField) bool {
+	parts := strings.Split(field.Tag.Get("json"), ",")
+	return parts[0] == "" && slices.Contains(parts[1:], "inline")
+}
+
+// isSpecialStructType reports whether the struct serializes as a single value (time.Time, sql.NullString, ...), so it can't get promoted.
+func isSpecialStructType(t reflect.Type) bool {
+	if _, _, ok := getSpecialTypeSchema(t); ok {
+		return true
+	}
+	return implementsSwaggerSchema(t) || implementsInterface(t, reflect.TypeFor[json.Marshaler]()) || implementsInterface(t, reflect.TypeFor[encoding.TextMarshaler]())
+}
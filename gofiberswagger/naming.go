@@ -0,0 +1,140 @@
+package gofiberswagger
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SchemaNamingStrategy returns the component name (the part after "#/components/schemas/") of a named type.
+// Anonymous structs don't get passed to the strategy, they're named after the component + field they're declared in.
+type SchemaNamingStrategy func(t reflect.Type) string
+
+// PackageQualifiedSchemaNames names components after the package path and the type, eg. "github_com_user_repo_modelsUser". (default)
+func PackageQualifiedSchemaNames(t reflect.Type) string {
+	return strings.ReplaceAll(strings.ReplaceAll(t.PkgPath(), "/", "_"), ".", "_") + schemaTypeName(t)
+}
+
+// ShortSchemaNames names components after the type only, eg. "User", or "PageUser" for Page[User].
+// Types with the same name from different packages collide, which makes Register return an error.
+func ShortSchemaNames(t reflect.Type) string {
+	return schemaTypeName(t)
+}
+
+// SetSchemaNamingStrategy sets the naming strategy of the default generator (used by the top-level functions).
+// Call it before creating any routes / schemas (see Config.SchemaNamingStrategy).
+func SetSchemaNamingStrategy(strategy SchemaNamingStrategy) {
+	defaultGenerator.SetSchemaNamingStrategy(strategy)
+}
+
+func (g *Generator) SetSchemaNamingStrategy(strategy SchemaNamingStrategy) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	g.config.SchemaNamingStrategy = strategy
+}
+
+// https://spec.openapis.org/oas/v3.1.1.html#fixed-fields-5
+var componentNameRegex = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+
+// schemaTypeName returns the name of the type with the type arguments of generics flattened into it,
+// eg. "Page[github.com/user/repo/models.User]" becomes "PageUser".
+func schemaTypeName(t reflect.Type) string {
+	name := t.Name()
+	start := strings.IndexByte(name, '[')
+	if start == -1 {
+		return name
+	}
+
+	result := strings.Builder{}
+	result.WriteString(name[:start])
+	arguments := strings.FieldsFunc(name[start:], func(r rune) bool {
+		return strings.ContainsRune("[]*, ", r)
+	})
+	for _, argument := range arguments {
+		argument = argument[strings.LastIndexByte(argument, '/')+1:]
+		if dot := strings.IndexByte(argument, '.'); dot != -1 {
+			argument = argument[dot+1:]
+		}
+		result.WriteString(upperFirst(strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, argument)))
+	}
+	return result.String()
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// nestedSchemaName returns the name hint for an anonymous type declared inside of the named one (empty if there's no name to build on).
+func nestedSchemaName(name string, suffix string) string {
+	if name == "" {
+		return ""
+	}
+	return name + upperFirst(suffix)
+}
+
+func (g *Generator) schemaNamingStrategy() SchemaNamingStrategy {
+	if g.config.SchemaNamingStrategy == nil {
+		return PackageQualifiedSchemaNames
+	}
+	return g.config.SchemaNamingStrategy
+}
+
+// schemaName returns the component name of the type. Every type keeps the name it got first,
+// and names claimed by two different types get recorded as errors (returned by Register).
+func (g *Generator) schemaName(t reflect.Type, nameHint string) string {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	if name, ok := g.schemaNames[t]; ok {
+		return name
+	}
+	if g.schemaNames == nil {
+		g.schemaNames = make(map[reflect.Type]string)
+		g.schemaNameOwners = make(map[string]reflect.Type)
+	}
+
+	name := nameHint
+	if t.Name() != "" {
+		name = g.schemaNamingStrategy()(t)
+	} else if name == "" {
+		hash := fnv.New32a()
+		hash.Write([]byte(t.String()))
+		name = fmt.Sprintf("AnonymousStruct%08x", hash.Sum32())
+	}
+
+	if !componentNameRegex.MatchString(name) {
+		g.schemaNameErrors = append(g.schemaNameErrors, fmt.Errorf("gofiber-swagger: schema name %q of %s is not a valid component name", name, t))
+	}
+	if owner, taken := g.schemaNameOwners[name]; taken && owner != t {
+		g.schemaNameErrors = append(g.schemaNameErrors, fmt.Errorf("gofiber-swagger: schema name %q is ambiguous, it's used by both %s and %s", name, owner, t))
+		// generation continues with a unique name, so the error gets reported by Register instead of corrupting the other schema
+		for i := 2; ; i++ {
+			if _, taken := g.schemaNameOwners[name+strconv.Itoa(i)]; !taken {
+				name += strconv.Itoa(i)
+				break
+			}
+		}
+	}
+
+	g.schemaNames[t] = name
+	g.schemaNameOwners[name] = t
+	return name
+}
+
+func (g *Generator) schemaNamingError() error {
+	g.schemasMutex.RLock()
+	defer g.schemasMutex.RUnlock()
+	return errors.Join(g.schemaNameErrors...)
+}
@@ -0,0 +1,139 @@
+package gofiberswagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type NamingUser struct {
+	Name string `json:"name"`
+}
+
+type NamingPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type NamingWithAnonymous struct {
+	Address struct {
+		Street string `json:"street"`
+	} `json:"address"`
+	Tags []struct {
+		Label string `json:"label"`
+	} `json:"tags"`
+}
+
+func TestSchemaTypeName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "NamingUser", schemaTypeName(reflect.TypeFor[NamingUser]()))
+	assert.Equal(t, "NamingPageNamingUser", schemaTypeName(reflect.TypeFor[NamingPage[NamingUser]]()))
+	assert.Equal(t, "NamingPageInt", schemaTypeName(reflect.TypeFor[NamingPage[int]]()))
+	assert.Equal(t, "NamingPageNamingPageNamingUser", schemaTypeName(reflect.TypeFor[NamingPage[NamingPage[NamingUser]]]()))
+	assert.Equal(t, "NamingPageMapStringNamingUser", schemaTypeName(reflect.TypeFor[NamingPage[map[string]*NamingUser]]()))
+}
+
+func TestSchemaNames_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	generate := func() []byte {
+		generator := NewGenerator(Config{SchemaNamingStrategy: ShortSchemaNames})
+		assert.Equal(t, "#/components/schemas/NamingPageNamingUser", generator.CreateSchema(reflect.TypeFor[NamingPage[NamingUser]]()).Ref)
+
+		schema := generator.CreateSchema(reflect.TypeFor[NamingWithAnonymous]())
+		assert.Equal(t, "#/components/schemas/NamingWithAnonymous", schema.Ref)
+		assert.Equal(t, "#/components/schemas/NamingWithAnonymousAddress", schema.Value.Properties["address"].Ref)
+		assert.Equal(t, "#/components/schemas/NamingWithAnonymousTagsItem", schema.Value.Properties["tags"].Value.Items.Ref)
+
+		anonymous := generator.CreateSchema(reflect.TypeFor[struct {
+			Value int `json:"value"`
+		}]())
+		assert.Regexp(t, "^#/components/schemas/AnonymousStruct[0-9a-f]{8}$", anonymous.Ref)
+
+		app := fiber.New()
+		config := Config{}
+		config.Swagger = swaggerConfigDefault(config.Swagger)
+		assert.NoError(t, generator.register(app, config))
+		result, err := json.Marshal(config.Swagger.Components.Schemas)
+		assert.NoError(t, err)
+		return result
+	}
+
+	assert.Equal(t, string(generate()), string(generate()))
+}
+
+func TestSchemaNames_PackageQualifiedByDefault(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{})
+	schema := generator.CreateSchema(reflect.TypeFor[NamingPage[NamingUser]]())
+	assert.Equal(t, "#/components/schemas/github_com_TDiblik_gofiber-swagger_gofiberswaggerNamingPageNamingUser", schema.Ref)
+	assert.Equal(t, "NamingPageNamingUser", schema.Value.Title)
+}
+
+func TestSchemaNames_CustomStrategyCollision(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{SchemaNamingStrategy: func(t reflect.Type) string {
+		return "Same"
+	}})
+	user := generator.CreateSchema(reflect.TypeFor[NamingUser]())
+	page := generator.CreateSchema(reflect.TypeFor[NamingPage[NamingUser]]())
+	assert.Equal(t, "#/components/schemas/Same", user.Ref)
+	assert.NotEqual(t, user.Ref, page.Ref)
+
+	app := fiber.New()
+	config := Config{}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	err := generator.register(app, config)
+	assert.ErrorContains(t, err, `schema name "Same" is ambiguous`)
+}
+
+func TestSchemaNames_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{SchemaNamingStrategy: func(t reflect.Type) string {
+		return "not valid"
+	}})
+	generator.CreateSchema(reflect.TypeFor[NamingUser]())
+
+	app := fiber.New()
+	config := Config{}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	assert.ErrorContains(t, generator.register(app, config), "not a valid component name")
+}
+
+func TestSchemaNames_StrategyOfTheRegisterConfig(t *testing.T) {
+	t.Parallel()
+
+	register := func(generator *Generator, strategy SchemaNamingStrategy) error {
+		config := Config{SchemaNamingStrategy: strategy}
+		config.Swagger = swaggerConfigDefault(config.Swagger)
+		return generator.register(fiber.New(), config)
+	}
+
+	// no schemas yet, the strategy gets applied
+	generator := NewGenerator(Config{})
+	assert.NoError(t, register(generator, ShortSchemaNames))
+	assert.Equal(t, "#/components/schemas/NamingUser", generator.CreateSchema(reflect.TypeFor[NamingUser]()).Ref)
+	assert.NoError(t, register(generator, ShortSchemaNames))
+
+	// the schemas were already generated using another strategy
+	generator = NewGenerator(Config{})
+	generator.CreateSchema(reflect.TypeFor[NamingUser]())
+	assert.NoError(t, register(generator, PackageQualifiedSchemaNames))
+	assert.ErrorContains(t, register(generator, ShortSchemaNames), "SchemaNamingStrategy of the config differs")
+
+	// the DefaultConfig doesn't override the strategy set before creating the routes
+	generator = NewGenerator(DefaultConfig)
+	generator.SetSchemaNamingStrategy(ShortSchemaNames)
+	generator.CreateSchema(reflect.TypeFor[NamingUser]())
+	config := DefaultConfig
+	config.CreateSwaggerFiles = false
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	assert.NoError(t, generator.register(fiber.New(), config))
+}
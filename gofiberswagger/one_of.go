@@ -0,0 +1,140 @@
+package gofiberswagger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+/// ------------------------------------------------------------------------------------------ ///
+/// Polymorphic schemas, fields of a registered interface become oneOf + discriminator schemas ///
+/// ------------------------------------------------------------------------------------------ ///
+
+// The discriminator property used by RegisterOneOf.
+const DefaultDiscriminatorProperty = "type"
+
+// OneOfVariant is a concrete implementation of an interface and the discriminator value identifying it.
+type OneOfVariant struct {
+	Value string
+	Type  reflect.Type
+}
+
+type oneOfRegistration struct {
+	discriminator string
+	variants      []OneOfVariant
+}
+
+// RegisterOneOf registers the implementations of the interface T, eg. `RegisterOneOf[PaymentMethod](Card{}, BankTransfer{})`.
+// Fields of type T then get documented as oneOf the implementations, with the "type" discriminator property
+// and the name of each implementation as its value ("Card", "BankTransfer").
+func RegisterOneOf[T any](variants ...T) {
+	registered := []OneOfVariant{}
+	for _, variant := range variants {
+		t := derefType(reflect.TypeOf(variant))
+		registered = append(registered, OneOfVariant{Value: schemaTypeName(t), Type: t})
+	}
+	defaultGenerator.RegisterOneOf(reflect.TypeFor[T](), DefaultDiscriminatorProperty, registered...)
+}
+
+// RegisterOneOfWithDiscriminator is the same as RegisterOneOf, but with a custom discriminator property and values,
+// eg. `RegisterOneOfWithDiscriminator[PaymentMethod]("method", map[string]PaymentMethod{"card": Card{}, "bank": BankTransfer{}})`.
+func RegisterOneOfWithDiscriminator[T any](discriminator string, variants map[string]T) {
+	registered := []OneOfVariant{}
+	for value, variant := range variants {
+		registered = append(registered, OneOfVariant{Value: value, Type: derefType(reflect.TypeOf(variant))})
+	}
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].Value < registered[j].Value
+	})
+	defaultGenerator.RegisterOneOf(reflect.TypeFor[T](), discriminator, registered...)
+}
+
+// RegisterOneOf registers the implementations of the interface type t (see the top-level RegisterOneOf).
+// Register the interface before creating schemas which use it, already generated schemas don't get updated.
+func (g *Generator) RegisterOneOf(t reflect.Type, discriminator string, variants ...OneOfVariant) {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+	if g.oneOfs == nil {
+		g.oneOfs = make(map[reflect.Type]*oneOfRegistration)
+	}
+	g.oneOfs[t] = &oneOfRegistration{discriminator: discriminator, variants: variants}
+}
+
+func (g *Generator) getOneOf(t reflect.Type) *oneOfRegistration {
+	g.schemasMutex.RLock()
+	defer g.schemasMutex.RUnlock()
+	if g.oneOfs == nil {
+		return nil
+	}
+	return g.oneOfs[t]
+}
+
+// generateOneOfSchema returns the oneOf + discriminator schema of a registered interface, or nil if it wasn't registered.
+// Every variant gets a named wrapper component, `allOf: [{$ref: Variant}, {discriminator property restricted to its value}]`,
+// so the discriminator mapping points to $refs (as the spec requires) while the shared component of the variant stays untouched.
+func (g *Generator) generateOneOfSchema(t reflect.Type) *SchemaRef {
+	registration := g.getOneOf(t)
+	if registration == nil {
+		return nil
+	}
+
+	schema := &Schema{
+		Discriminator: &Discriminator{PropertyName: registration.discriminator, Mapping: openapi3.StringMap[openapi3.MappingRef]{}},
+	}
+	for _, variant := range registration.variants {
+		variantSchema := g.generateSchema(variant.Type, false, "")
+		if variantSchema == nil || variantSchema.Ref == "" {
+			continue
+		}
+		discriminator := &Schema{
+			Type:       &Types{"object"},
+			Properties: Schemas{registration.discriminator: {Value: &Schema{Type: &Types{"string"}, Enum: []any{variant.Value}}}},
+			Required:   []string{registration.discriminator},
+		}
+		wrapper := &Schema{
+			Title: schemaTypeName(variant.Type),
+			AllOf: SchemaRefs{{Ref: variantSchema.Ref, Value: variantSchema.Value}, {Value: discriminator}},
+		}
+
+		name := g.oneOfVariantName(t, variant)
+		g.setToAcquiredSchemas(name, &SchemaRef{Value: wrapper})
+		ref := "#/components/schemas/" + name
+		schema.OneOf = append(schema.OneOf, &SchemaRef{Ref: ref, Value: wrapper})
+		schema.Discriminator.Mapping[variant.Value] = openapi3.MappingRef{Ref: ref}
+	}
+	return &SchemaRef{Value: schema}
+}
+
+// oneOfVariantName names the wrapper component of the variant after the interface and the discriminator value,
+// eg. "PaymentMethodCard". Names claimed by a type get recorded as errors (returned by Register), the same way schemaName does.
+// Called by every field using the interface, the name gets checked only the first time.
+func (g *Generator) oneOfVariantName(t reflect.Type, variant OneOfVariant) string {
+	g.schemasMutex.Lock()
+	defer g.schemasMutex.Unlock()
+
+	name := schemaTypeName(variant.Type)
+	if t.Name() != "" {
+		name = g.schemaNamingStrategy()(t)
+	}
+	name += upperFirst(strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, variant.Value))
+	if g.acquiredSchemas[name] != nil {
+		return name
+	}
+
+	if !componentNameRegex.MatchString(name) {
+		g.schemaNameErrors = append(g.schemaNameErrors, fmt.Errorf("gofiber-swagger: schema name %q of the %s variant of %s is not a valid component name", name, variant.Type, t))
+	}
+	if owner, taken := g.schemaNameOwners[name]; taken {
+		g.schemaNameErrors = append(g.schemaNameErrors, fmt.Errorf("gofiber-swagger: schema name %q is ambiguous, it's used by both %s and the %s variant of %s", name, owner, variant.Type, t))
+	}
+	return name
+}
@@ -0,0 +1,138 @@
+package gofiberswagger
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type OneOfPaymentMethod interface {
+	isPaymentMethod()
+}
+
+type OneOfCard struct {
+	Number string `json:"number"`
+}
+
+func (OneOfCard) isPaymentMethod() {}
+
+type OneOfBankTransfer struct {
+	Type string `json:"type"`
+	Iban string `json:"iban"`
+}
+
+func (*OneOfBankTransfer) isPaymentMethod() {}
+
+type OneOfOrder struct {
+	Payment  OneOfPaymentMethod   `json:"payment"`
+	Previous []OneOfPaymentMethod `json:"previous"`
+	Other    any                  `json:"other"`
+}
+
+func TestRegisterOneOf(t *testing.T) {
+	t.Parallel()
+
+	RegisterOneOf[OneOfPaymentMethod](OneOfCard{}, &OneOfBankTransfer{})
+
+	order := CreateSchema[OneOfOrder]()
+	payment := order.Value.Properties["payment"].Value
+	assert.Len(t, payment.OneOf, 2)
+	card_ref := "#/components/schemas/" + PackageQualifiedSchemaNames(reflect.TypeFor[OneOfCard]())
+	bank_ref := "#/components/schemas/" + PackageQualifiedSchemaNames(reflect.TypeFor[OneOfBankTransfer]())
+	assert.Equal(t, "type", payment.Discriminator.PropertyName)
+
+	// every variant gets a wrapper component restricting its discriminator value, mapped from the value
+	for i, expected := range []struct{ ref, value string }{{card_ref, "OneOfCard"}, {bank_ref, "OneOfBankTransfer"}} {
+		wrapper_ref := "#/components/schemas/" + PackageQualifiedSchemaNames(reflect.TypeFor[OneOfPaymentMethod]()) + expected.value
+		assert.Equal(t, wrapper_ref, payment.OneOf[i].Ref)
+		assert.Equal(t, wrapper_ref, payment.Discriminator.Mapping[expected.value].Ref)
+		wrapper := payment.OneOf[i].Value
+		assert.Len(t, wrapper.AllOf, 2)
+		assert.Equal(t, expected.ref, wrapper.AllOf[0].Ref)
+		assert.Equal(t, []any{expected.value}, wrapper.AllOf[1].Value.Properties["type"].Value.Enum)
+		assert.Equal(t, []string{"type"}, wrapper.AllOf[1].Value.Required)
+	}
+
+	// the shared components of the variants stay untouched
+	card := payment.OneOf[0].Value.AllOf[0].Value
+	assert.NotContains(t, card.Properties, "type")
+	assert.NotContains(t, card.Required, "type")
+	bank := payment.OneOf[1].Value.AllOf[0].Value
+	assert.Nil(t, bank.Properties["type"].Value.Enum)
+	assert.NotContains(t, bank.Required, "type")
+
+	previous := order.Value.Properties["previous"].Value
+	assert.Equal(t, "array", (*previous.Type)[0])
+	assert.Len(t, previous.Items.Value.OneOf, 2)
+
+	assert.Equal(t, "object", (*order.Value.Properties["other"].Value.Type)[0])
+	assert.Nil(t, order.Value.Properties["other"].Value.OneOf)
+}
+
+func TestGenerator_RegisterOneOfWithDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	generator.RegisterOneOf(reflect.TypeFor[OneOfPaymentMethod](), "method",
+		OneOfVariant{Value: "card", Type: reflect.TypeFor[OneOfCard]()},
+		OneOfVariant{Value: "bank", Type: reflect.TypeFor[OneOfBankTransfer]()},
+	)
+	router := generator.NewRouter(app)
+	router.Post("/orders", &RouteInfo{
+		Responses: NewResponsesRaw(map[string]*ResponseRef{
+			"200": {Value: openapi3.NewResponse().WithDescription("OK").WithJSONSchemaRef(generator.CreateSchema(reflect.TypeFor[OneOfOrder]()))},
+		}),
+	}, func(c fiber.Ctx) error { return nil })
+
+	config := Config{}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	assert.NoError(t, generator.register(app, config))
+
+	payment := generator.CreateSchema(reflect.TypeFor[OneOfOrder]()).Value.Properties["payment"].Value
+	assert.Equal(t, "method", payment.Discriminator.PropertyName)
+	wrapper_name := PackageQualifiedSchemaNames(reflect.TypeFor[OneOfPaymentMethod]()) + "Card"
+	assert.Equal(t, "#/components/schemas/"+wrapper_name, payment.Discriminator.Mapping["card"].Ref)
+	assert.Equal(t, []any{"card"}, payment.OneOf[0].Value.AllOf[1].Value.Properties["method"].Value.Enum)
+	assert.Equal(t, []any{"bank"}, payment.OneOf[1].Value.AllOf[1].Value.Properties["method"].Value.Enum)
+
+	// the variants and their wrappers end up in the components
+	assert.Contains(t, config.Swagger.Components.Schemas, wrapper_name)
+	assert.Contains(t, config.Swagger.Components.Schemas, PackageQualifiedSchemaNames(reflect.TypeFor[OneOfCard]()))
+	assert.Contains(t, config.Swagger.Components.Schemas, PackageQualifiedSchemaNames(reflect.TypeFor[OneOfBankTransfer]()))
+}
+
+func TestRegisterOneOf_ValidatesTheDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{FilterOutAppUse: true})
+	generator.RegisterOneOf(reflect.TypeFor[OneOfPaymentMethod](), DefaultDiscriminatorProperty,
+		OneOfVariant{Value: "card", Type: reflect.TypeFor[OneOfCard]()},
+		OneOfVariant{Value: "bank", Type: reflect.TypeFor[OneOfBankTransfer]()},
+	)
+	app.Use(generator.NewRequestValidator())
+	router := generator.NewRouter(app)
+	router.Post("/orders", &RouteInfo{
+		RequestBody: &RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(generator.CreateSchema(reflect.TypeFor[OneOfOrder]()))},
+	}, func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	assert.NoError(t, generator.Register(app))
+
+	for body, expected := range map[string]int{
+		`{"payment":{"type":"card","number":"4242"}}`: fiber.StatusOK,
+		`{"payment":{"type":"bank","iban":"CZ65"}}`:   fiber.StatusOK,
+		`{"payment":{"type":"cash","number":"4242"}}`: fiber.StatusBadRequest,
+		`{"payment":{"number":"4242"}}`:               fiber.StatusBadRequest,
+	} {
+		req := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, resp.StatusCode, body)
+	}
+}
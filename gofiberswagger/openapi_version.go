@@ -0,0 +1,344 @@
+package gofiberswagger
+
+import (
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+/// ------------------------------------------------------------------------------------- ///
+/// Output version of the document, schemas get converted into valid 3.0 or 3.1 schemas  ///
+/// ------------------------------------------------------------------------------------- ///
+
+// OpenAPIVersion is the version of the generated document.
+type OpenAPIVersion string
+
+const (
+	// Nullable types are documented as `nullable: true`, 3.1 keywords (if / then / else, const, dependentRequired, ...)
+	// get rewritten into their 3.0 equivalents, or dropped if there isn't any (propertyNames, contentMediaType, ...).
+	OpenAPIVersion30 OpenAPIVersion = "3.0.3"
+	// Nullable types are documented as type arrays (`type: ["string", "null"]`), examples as `examples`
+	// and single value enums as `const`.
+	OpenAPIVersion31 OpenAPIVersion = "3.1.1"
+)
+
+// convertDocumentVersion returns a deep copy of the document with every schema converted into the version declared by doc.OpenAPI.
+// Schemas are generated in a version independent form (`nullable`, `example`, 3.1 validation keywords),
+// the generator caches them and every registered app can declare a different version, so they can't be converted in place.
+func convertDocumentVersion(doc openapi3.T) (*openapi3.T, error) {
+	if !doc.IsOpenAPI30() && !doc.IsOpenAPI31OrLater() {
+		return nil, errors.New("gofiber-swagger: unsupported OpenAPI version \"" + doc.OpenAPI + "\", use 3.0.x or 3.1.x")
+	}
+
+	converted, err := copyDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	convert := upgradeSchemaTo31
+	if converted.IsOpenAPI30() {
+		convert = downgradeSchemaTo30
+		converted.Webhooks = nil
+		converted.JSONSchemaDialect = ""
+	}
+	walkDocumentSchemas(converted, convert)
+	return converted, nil
+}
+
+// copyDocument returns a deep copy of the document. References of the copy aren't resolved (SchemaRef.Value of $refs is nil).
+func copyDocument(doc openapi3.T) (*openapi3.T, error) {
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	copied := &openapi3.T{}
+	if err := copied.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+func upgradeSchemaTo31(schema *Schema) {
+	if schema.Nullable {
+		schema.Nullable = false
+		switch {
+		case schema.Type != nil && len(*schema.Type) > 0:
+			if !schema.Type.Includes("null") {
+				*schema.Type = append(*schema.Type, "null")
+			}
+			if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, nil) {
+				schema.Enum = append(schema.Enum, nil)
+			}
+		case len(schema.OneOf) > 0 && schema.Discriminator == nil:
+			schema.OneOf = append(schema.OneOf, &SchemaRef{Value: &Schema{Type: &Types{"null"}}})
+		case len(schema.AnyOf) > 0:
+			schema.AnyOf = append(schema.AnyOf, &SchemaRef{Value: &Schema{Type: &Types{"null"}}})
+		case len(schema.AllOf) > 0:
+			// nullable components (eg. pointer-to-struct fields, see wrapRefSiblings) -> `anyOf: [{$ref}, {type: null}]`
+			component := schema.AllOf[0]
+			if len(schema.AllOf) > 1 {
+				component = &SchemaRef{Value: &Schema{AllOf: schema.AllOf}}
+			}
+			schema.AllOf, schema.AnyOf = nil, SchemaRefs{component, {Value: &Schema{Type: &Types{"null"}}}}
+		}
+	}
+
+	if schema.Example != nil {
+		if len(schema.Examples) == 0 {
+			schema.Examples = []any{schema.Example}
+		}
+		schema.Example = nil
+	}
+
+	if len(schema.Enum) == 1 && schema.Const == nil {
+		schema.Const, schema.Enum = schema.Enum[0], nil
+	}
+
+	if schema.ExclusiveMin.Bool != nil {
+		if *schema.ExclusiveMin.Bool && schema.Min != nil {
+			schema.ExclusiveMin, schema.Min = openapi3.ExclusiveBound{Value: schema.Min}, nil
+		} else {
+			schema.ExclusiveMin = openapi3.ExclusiveBound{}
+		}
+	}
+	if schema.ExclusiveMax.Bool != nil {
+		if *schema.ExclusiveMax.Bool && schema.Max != nil {
+			schema.ExclusiveMax, schema.Max = openapi3.ExclusiveBound{Value: schema.Max}, nil
+		} else {
+			schema.ExclusiveMax = openapi3.ExclusiveBound{}
+		}
+	}
+}
+
+func downgradeSchemaTo30(schema *Schema) {
+	if schema.Type != nil && schema.Type.Includes("null") {
+		types := slices.DeleteFunc(slices.Clone(*schema.Type), func(t string) bool { return t == "null" })
+		schema.Nullable = true
+		switch len(types) {
+		case 0:
+			schema.Type = nil
+		case 1:
+			schema.Type = &Types{types[0]}
+		default:
+			schema.Type = nil
+			for _, t := range types {
+				schema.AnyOf = append(schema.AnyOf, &SchemaRef{Value: &Schema{Type: &Types{t}}})
+			}
+		}
+		schema.Enum = slices.DeleteFunc(schema.Enum, func(value any) bool { return value == nil })
+	} else if schema.Type != nil && len(*schema.Type) > 1 {
+		for _, t := range *schema.Type {
+			schema.AnyOf = append(schema.AnyOf, &SchemaRef{Value: &Schema{Type: &Types{t}}})
+		}
+		schema.Type = nil
+	}
+
+	if schema.Const != nil {
+		schema.Enum, schema.Const = []any{schema.Const}, nil
+	}
+	if len(schema.Examples) > 0 {
+		if schema.Example == nil {
+			schema.Example = schema.Examples[0]
+		}
+		schema.Examples = nil
+	}
+
+	exclusive := true
+	if schema.ExclusiveMin.Value != nil {
+		schema.Min, schema.ExclusiveMin = schema.ExclusiveMin.Value, openapi3.ExclusiveBound{Bool: &exclusive}
+	}
+	if schema.ExclusiveMax.Value != nil {
+		schema.Max, schema.ExclusiveMax = schema.ExclusiveMax.Value, openapi3.ExclusiveBound{Bool: &exclusive}
+	}
+
+	// `if A then B else C` is the same as `anyOf: [allOf: [A, B], allOf: [not A, C]]`
+	if schema.If != nil {
+		then, otherwise := schema.Then, schema.Else
+		if then == nil {
+			then = &SchemaRef{Value: &Schema{}}
+		}
+		if otherwise == nil {
+			otherwise = &SchemaRef{Value: &Schema{}}
+		}
+		schema.AllOf = append(schema.AllOf, &SchemaRef{Value: &Schema{AnyOf: SchemaRefs{
+			{Value: &Schema{AllOf: SchemaRefs{schema.If, then}}},
+			{Value: &Schema{AllOf: SchemaRefs{{Value: &Schema{Not: schema.If}}, otherwise}}},
+		}}})
+	}
+	schema.If, schema.Then, schema.Else = nil, nil, nil
+
+	// `dependentRequired: {A: [B]}` / `dependentSchemas: {A: S}` is the same as `anyOf: [not: {required: [A]}, S]`
+	for _, property := range sortedKeys(schema.DependentRequired) {
+		schema.AllOf = append(schema.AllOf, dependentSchema30(property, &SchemaRef{Value: &Schema{Required: schema.DependentRequired[property]}}))
+	}
+	for _, property := range sortedKeys(schema.DependentSchemas) {
+		schema.AllOf = append(schema.AllOf, dependentSchema30(property, schema.DependentSchemas[property]))
+	}
+	schema.DependentRequired, schema.DependentSchemas = nil, nil
+
+	if len(schema.PatternProperties) > 0 && schema.AdditionalProperties.Schema == nil && schema.AdditionalProperties.Has == nil {
+		patterns := sortedKeys(schema.PatternProperties)
+		if len(patterns) == 1 {
+			schema.AdditionalProperties.Schema = schema.PatternProperties[patterns[0]]
+		} else {
+			anyOf := SchemaRefs{}
+			for _, pattern := range patterns {
+				anyOf = append(anyOf, schema.PatternProperties[pattern])
+			}
+			schema.AdditionalProperties.Schema = &SchemaRef{Value: &Schema{AnyOf: anyOf}}
+		}
+	}
+	schema.PatternProperties = nil
+
+	if schema.ContentEncoding == "base64" && schema.Format == "" {
+		schema.Format = "byte"
+	}
+
+	// no 3.0 equivalents
+	schema.PropertyNames, schema.PrefixItems, schema.Contains, schema.MinContains, schema.MaxContains = nil, nil, nil, nil, nil
+	schema.UnevaluatedItems, schema.UnevaluatedProperties = openapi3.BoolSchema{}, openapi3.BoolSchema{}
+	schema.ContentMediaType, schema.ContentEncoding, schema.ContentSchema = "", "", nil
+	schema.Defs, schema.SchemaDialect, schema.Comment = nil, "", ""
+	schema.SchemaID, schema.Anchor, schema.DynamicRef, schema.DynamicAnchor = "", "", "", ""
+}
+
+func dependentSchema30(property string, dependent *SchemaRef) *SchemaRef {
+	return &SchemaRef{Value: &Schema{AnyOf: SchemaRefs{
+		{Value: &Schema{Not: &SchemaRef{Value: &Schema{Required: []string{property}}}}},
+		dependent,
+	}}}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/// ---- ///
+
+// schemaChildren returns every (non-nil) sub-schema of the schema: properties, items, allOf, if / then / else, ...
+func schemaChildren(schema *Schema) SchemaRefs {
+	children := SchemaRefs{}
+	for _, child := range []*SchemaRef{schema.Items, schema.Not, schema.AdditionalProperties.Schema, schema.If, schema.Then, schema.Else,
+		schema.PropertyNames, schema.Contains, schema.ContentSchema, schema.UnevaluatedItems.Schema, schema.UnevaluatedProperties.Schema} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	children = append(children, schema.AllOf...)
+	children = append(children, schema.AnyOf...)
+	children = append(children, schema.OneOf...)
+	children = append(children, schema.PrefixItems...)
+	for _, schemas := range []Schemas{schema.Properties, schema.PatternProperties, schema.DependentSchemas, schema.Defs} {
+		for _, key := range sortedKeys(schemas) {
+			children = append(children, schemas[key])
+		}
+	}
+	return children
+}
+
+// walkDocumentSchemas calls visit on every inline schema of the document, parents before their children.
+func walkDocumentSchemas(doc *openapi3.T, visit func(schema *Schema)) {
+	visited := map[*Schema]bool{}
+	var walkSchema func(ref *SchemaRef)
+	walkSchema = func(ref *SchemaRef) {
+		if ref == nil || ref.Value == nil || visited[ref.Value] {
+			return
+		}
+		visited[ref.Value] = true
+		visit(ref.Value)
+		for _, child := range schemaChildren(ref.Value) {
+			walkSchema(child)
+		}
+	}
+
+	walkContent := func(content Content) {
+		for _, media_type := range content {
+			if media_type != nil {
+				walkSchema(media_type.Schema)
+			}
+		}
+	}
+	walkParameter := func(ref *ParameterRef) {
+		if ref != nil && ref.Value != nil {
+			walkSchema(ref.Value.Schema)
+			walkContent(ref.Value.Content)
+		}
+	}
+	walkHeaders := func(headers openapi3.Headers) {
+		for _, header := range headers {
+			if header != nil && header.Value != nil {
+				walkSchema(header.Value.Schema)
+				walkContent(header.Value.Content)
+			}
+		}
+	}
+	walkResponse := func(ref *openapi3.ResponseRef) {
+		if ref != nil && ref.Value != nil {
+			walkHeaders(ref.Value.Headers)
+			walkContent(ref.Value.Content)
+		}
+	}
+	walkRequestBody := func(ref *openapi3.RequestBodyRef) {
+		if ref != nil && ref.Value != nil {
+			walkContent(ref.Value.Content)
+		}
+	}
+	var walkPathItem func(path_item *PathItem)
+	walkPathItem = func(path_item *PathItem) {
+		if path_item == nil {
+			return
+		}
+		for _, parameter := range path_item.Parameters {
+			walkParameter(parameter)
+		}
+		for _, operation := range path_item.Operations() {
+			for _, parameter := range operation.Parameters {
+				walkParameter(parameter)
+			}
+			walkRequestBody(operation.RequestBody)
+			if operation.Responses != nil {
+				for _, response := range operation.Responses.Map() {
+					walkResponse(response)
+				}
+			}
+			for _, callback := range operation.Callbacks {
+				if callback != nil && callback.Value != nil {
+					for _, callback_path_item := range callback.Value.Map() {
+						walkPathItem(callback_path_item)
+					}
+				}
+			}
+		}
+	}
+
+	if components := doc.Components; components != nil {
+		for _, schema := range components.Schemas {
+			walkSchema(schema)
+		}
+		for _, parameter := range components.Parameters {
+			walkParameter(parameter)
+		}
+		walkHeaders(components.Headers)
+		for _, request_body := range components.RequestBodies {
+			walkRequestBody(request_body)
+		}
+		for _, response := range components.Responses {
+			walkResponse(response)
+		}
+	}
+	if doc.Paths != nil {
+		for _, path_item := range doc.Paths.Map() {
+			walkPathItem(path_item)
+		}
+	}
+	for _, path_item := range doc.Webhooks {
+		walkPathItem(path_item)
+	}
+}
@@ -0,0 +1,167 @@
+package gofiberswagger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type OpenAPIVersionBody struct {
+	Name      *string           `json:"name" example:"John"`
+	Nickname  sql.NullString    `json:"nickname"`
+	Kind      string            `json:"kind" validate:"oneof=person"`
+	Age       int               `json:"age" validate:"gt=0"`
+	Email     string            `json:"email" validate:"required_if=Kind person"`
+	Phone     string            `json:"phone" validate:"required_with=Email"`
+	Labels    map[string]string `json:"labels" validate:"dive,keys,alpha,endkeys"`
+	Payload   string            `json:"payload" validate:"json"`
+	Reference *OpenAPIVersionId `json:"reference"`
+	Payment   OneOfPaymentMethod
+}
+
+type OpenAPIVersionId struct {
+	Id int `json:"id"`
+}
+
+func generateVersionedDocument(t *testing.T, version OpenAPIVersion) (*openapi3.T, map[string]any) {
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	generator.RegisterOneOf(reflect.TypeFor[OneOfPaymentMethod](), DefaultDiscriminatorProperty,
+		OneOfVariant{Value: "card", Type: reflect.TypeFor[OneOfCard]()},
+		OneOfVariant{Value: "bank", Type: reflect.TypeFor[OneOfBankTransfer]()},
+	)
+	router := generator.NewRouter(app)
+	router.Post("/users", &RouteInfo{
+		RequestBody: NewRequestBodyJSON[OpenAPIVersionBody](),
+		Responses:   NewResponses(NewResponseInfo[OpenAPIVersionBody]("200", "OK")),
+	}, func(c fiber.Ctx) error { return nil })
+
+	return generateTestDocument(t, app, generator, Config{OpenAPIVersion: version})
+}
+
+// generateTestDocument registers the app using the config, validates the generated document and returns it
+// together with its JSON decoded into a map.
+func generateTestDocument(t *testing.T, app *fiber.App, generator *Generator, config Config) (*openapi3.T, map[string]any) {
+	config.Swagger = swaggerConfigDefault(DefaultSwaggerConfig)
+	assert.NoError(t, generator.register(app, config))
+
+	raw := generator.getDocumentSnapshot().schemaAsJson
+	document, err := openapi3.NewLoader().LoadFromData(raw)
+	assert.NoError(t, err)
+	assert.NoError(t, document.Validate(context.Background()))
+
+	as_map := map[string]any{}
+	assert.NoError(t, json.Unmarshal(raw, &as_map))
+	return document, as_map
+}
+
+func versionedBodyProperties(as_map map[string]any) map[string]any {
+	schemas := as_map["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, schema := range schemas {
+		if schema.(map[string]any)["title"] == "OpenAPIVersionBody" {
+			return schema.(map[string]any)["properties"].(map[string]any)
+		}
+	}
+	return nil
+}
+
+func TestOpenAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("3.1", func(t *testing.T) {
+		t.Parallel()
+
+		document, as_map := generateVersionedDocument(t, OpenAPIVersion31)
+		assert.Equal(t, "3.1.1", document.OpenAPI)
+
+		properties := versionedBodyProperties(as_map)
+		name := properties["name"].(map[string]any)
+		assert.Equal(t, []any{"string", "null"}, name["type"])
+		assert.Equal(t, []any{"John"}, name["examples"])
+		assert.Nil(t, name["nullable"])
+		assert.Nil(t, name["example"])
+
+		assert.Equal(t, []any{"string", "null"}, properties["nickname"].(map[string]any)["type"])
+		assert.Equal(t, "person", properties["kind"].(map[string]any)["const"])
+		assert.Equal(t, float64(0), properties["age"].(map[string]any)["exclusiveMinimum"])
+		assert.Equal(t, "application/json", properties["payload"].(map[string]any)["contentMediaType"])
+
+		// pointer-to-struct fields
+		reference := properties["reference"].(map[string]any)
+		assert.Equal(t, []any{
+			map[string]any{"$ref": "#/components/schemas/github_com_TDiblik_gofiber-swagger_gofiberswaggerOpenAPIVersionId"},
+			map[string]any{"type": "null"},
+		}, reference["anyOf"])
+		assert.Nil(t, reference["allOf"])
+		assert.Nil(t, reference["nullable"])
+	})
+
+	t.Run("3.0", func(t *testing.T) {
+		t.Parallel()
+
+		document, as_map := generateVersionedDocument(t, OpenAPIVersion30)
+		assert.Equal(t, "3.0.3", document.OpenAPI)
+
+		properties := versionedBodyProperties(as_map)
+		name := properties["name"].(map[string]any)
+		assert.Equal(t, "string", name["type"])
+		assert.Equal(t, true, name["nullable"])
+		assert.Equal(t, "John", name["example"])
+		assert.Nil(t, name["examples"])
+
+		assert.Equal(t, []any{"person"}, properties["kind"].(map[string]any)["enum"])
+		age := properties["age"].(map[string]any)
+		assert.Equal(t, true, age["exclusiveMinimum"])
+		assert.Equal(t, float64(0), age["minimum"])
+		assert.Nil(t, properties["labels"].(map[string]any)["propertyNames"])
+		assert.Nil(t, properties["payload"].(map[string]any)["contentMediaType"])
+
+		// pointer-to-struct fields
+		reference := properties["reference"].(map[string]any)
+		assert.Equal(t, []any{map[string]any{"$ref": "#/components/schemas/github_com_TDiblik_gofiber-swagger_gofiberswaggerOpenAPIVersionId"}}, reference["allOf"])
+		assert.Equal(t, true, reference["nullable"])
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := generateOpenApiSchema(openapi3.T{OpenAPI: "2.0"})
+		assert.Error(t, err)
+	})
+}
+
+func TestDowngradeSchemaTo30(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Type:              &Types{"object"},
+		If:                &SchemaRef{Value: &Schema{Required: []string{"a"}}},
+		Then:              &SchemaRef{Value: &Schema{Required: []string{"b"}}},
+		DependentRequired: map[string][]string{"c": {"d"}},
+		PatternProperties: Schemas{"^[0-9]+$": {Value: &Schema{Type: &Types{"string"}}}},
+	}
+	downgradeSchemaTo30(schema)
+
+	assert.Nil(t, schema.If)
+	assert.Nil(t, schema.Then)
+	assert.Nil(t, schema.DependentRequired)
+	assert.Nil(t, schema.PatternProperties)
+	assert.Len(t, schema.AllOf, 2)
+	assert.Equal(t, []string{"a"}, schema.AllOf[0].Value.AnyOf[0].Value.AllOf[0].Value.Required)
+	assert.Equal(t, []string{"a"}, schema.AllOf[0].Value.AnyOf[1].Value.AllOf[0].Value.Not.Value.Required)
+	assert.Equal(t, []string{"c"}, schema.AllOf[1].Value.AnyOf[0].Value.Not.Value.Required)
+	assert.Equal(t, []string{"d"}, schema.AllOf[1].Value.AnyOf[1].Value.Required)
+	assert.Equal(t, &Types{"string"}, schema.AdditionalProperties.Schema.Value.Type)
+
+	nullable := &Schema{Type: &Types{"integer", "null"}, Enum: []any{1, nil}}
+	downgradeSchemaTo30(nullable)
+	assert.Equal(t, &Types{"integer"}, nullable.Type)
+	assert.True(t, nullable.Nullable)
+	assert.Equal(t, []any{1}, nullable.Enum)
+}
@@ -0,0 +1,96 @@
+package gofiberswagger
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"unicode"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// OperationIdStrategy returns the operationId of a route which doesn't have one set manually (empty for none).
+// handler is the last handler of the route (nil if the route wasn't registered with any).
+type OperationIdStrategy func(method string, path string, info *RouteInfo, handler fiber.Handler) string
+
+// OperationIdFromHandlerName names operations after their handler function, eg. "GetUser" for `func GetUser(c fiber.Ctx) error`
+// or `(*Server).GetUser`. Anonymous functions fall back to OperationIdFromMethodAndPath.
+func OperationIdFromHandlerName(method string, path string, info *RouteInfo, handler fiber.Handler) string {
+	if name := handlerName(handler); name != "" {
+		return name
+	}
+	return OperationIdFromMethodAndPath(method, path, info, handler)
+}
+
+// OperationIdFromMethodAndPath names operations after the method and the path, eg. "getUsersById" for "GET /users/:id".
+func OperationIdFromMethodAndPath(method string, path string, info *RouteInfo, handler fiber.Handler) string {
+	operation_id := strings.Builder{}
+	operation_id.WriteString(strings.ToLower(method))
+	for _, segment := range parseRoutePath(path) {
+		switch {
+		case segment.param == nil:
+			operation_id.WriteString(pascalCaseWords(segment.literal))
+		case segment.param.greedy:
+			operation_id.WriteString("Wildcard")
+		default:
+			operation_id.WriteString("By" + pascalCaseWords(segment.param.name))
+		}
+	}
+	if operation_id.Len() == len(method) {
+		operation_id.WriteString("Root")
+	}
+	return operation_id.String()
+}
+
+var anonymousFuncRegex = regexp.MustCompile(`^func\d+$`)
+
+// handlerName returns the name of the handler function without the package (empty for closures / adapted handlers).
+func handlerName(handler fiber.Handler) string {
+	if handler == nil {
+		return ""
+	}
+	function := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if function == nil {
+		return ""
+	}
+
+	name := strings.TrimSuffix(function.Name(), "-fm")
+	if bracket := strings.IndexByte(name, '['); bracket != -1 {
+		name = name[:bracket]
+	}
+	name = name[strings.LastIndexByte(name, '/')+1:]
+	name = name[strings.LastIndexByte(name, '.')+1:]
+	if anonymousFuncRegex.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+func pascalCaseWords(raw string) string {
+	words := strings.FieldsFunc(raw, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	result := strings.Builder{}
+	for _, word := range words {
+		result.WriteString(upperFirst(word))
+	}
+	return result.String()
+}
+
+func routeHandler(route fiber.Route) fiber.Handler {
+	if len(route.Handlers) == 0 {
+		return nil
+	}
+	return route.Handlers[len(route.Handlers)-1]
+}
+
+type operationIdOwner struct {
+	method string
+	path   string
+}
+
+func duplicateOperationIdError(operation_id string, first operationIdOwner, second operationIdOwner) error {
+	return fmt.Errorf("gofiber-swagger: duplicate operationId %q used by both \"%s %s\" and \"%s %s\"", operation_id, first.method, first.path, second.method, second.path)
+}
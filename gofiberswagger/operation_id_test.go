@@ -0,0 +1,95 @@
+package gofiberswagger
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func OperationIdTestHandler(c fiber.Ctx) error {
+	return c.SendStatus(200)
+}
+
+type operationIdTestServer struct{}
+
+func (s *operationIdTestServer) ListUsers(c fiber.Ctx) error {
+	return c.SendStatus(200)
+}
+
+func TestOperationIdFromHandlerName(t *testing.T) {
+	t.Parallel()
+
+	server := &operationIdTestServer{}
+	closure := func(c fiber.Ctx) error { return nil }
+
+	assert.Equal(t, "OperationIdTestHandler", OperationIdFromHandlerName("GET", "/", nil, OperationIdTestHandler))
+	assert.Equal(t, "ListUsers", OperationIdFromHandlerName("GET", "/users", nil, server.ListUsers))
+	assert.Equal(t, "getUsersById", OperationIdFromHandlerName("GET", "/users/:id", nil, closure))
+	assert.Equal(t, "getUsersById", OperationIdFromHandlerName("GET", "/users/:id", nil, nil))
+}
+
+func TestOperationIdFromMethodAndPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{"GET", "/", "getRoot"},
+		{"POST", "/parameters/:id", "postParametersById"},
+		{"GET", "/users/:user_id<int>/posts", "getUsersByUserIdPosts"},
+		{"DELETE", "/files/*", "deleteFilesWildcard"},
+		{"GET", "/api/v1/health-check", "getApiV1HealthCheck"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, OperationIdFromMethodAndPath(tc.method, tc.path, nil, nil))
+	}
+}
+
+func TestRegister_OperationIds(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+	router.Get("/named", nil, OperationIdTestHandler)
+	router.Get("/manual", &RouteInfo{OperationID: "manualId"}, func(c fiber.Ctx) error { return nil })
+	router.Get("/files/:name?", nil, func(c fiber.Ctx) error { return nil })
+
+	config := Config{OperationIdStrategy: OperationIdFromHandlerName}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	assert.NoError(t, generator.register(app, config))
+
+	assert.Equal(t, "OperationIdTestHandler", config.Swagger.Paths.Find("/named").Get.OperationID)
+	assert.Equal(t, "manualId", config.Swagger.Paths.Find("/manual").Get.OperationID)
+	assert.Equal(t, "getFilesByName", config.Swagger.Paths.Find("/files/{name}").Get.OperationID)
+	assert.Equal(t, "getFilesByNameWithoutName", config.Swagger.Paths.Find("/files").Get.OperationID)
+}
+
+func TestRegister_DuplicateOperationIds(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+	router.Get("/a", nil, OperationIdTestHandler)
+	router.Get("/b", nil, OperationIdTestHandler)
+
+	config := Config{OperationIdStrategy: OperationIdFromHandlerName}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	err := generator.register(app, config)
+	assert.ErrorContains(t, err, `duplicate operationId "OperationIdTestHandler" used by both "GET /a" and "GET /b"`)
+
+	// without a strategy, manually set duplicates get detected as well
+	manual_app := fiber.New()
+	manual_generator := NewGenerator(Config{})
+	manual_router := manual_generator.NewRouter(manual_app)
+	manual_router.Get("/a", &RouteInfo{OperationID: "same"}, OperationIdTestHandler)
+	manual_router.Post("/b", &RouteInfo{OperationID: "same"}, OperationIdTestHandler)
+
+	manual_config := Config{}
+	manual_config.Swagger = swaggerConfigDefault(manual_config.Swagger)
+	assert.ErrorContains(t, manual_generator.register(manual_app, manual_config), `duplicate operationId "same"`)
+}
@@ -0,0 +1,87 @@
+package gofiberswagger
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Struct tags used by fiber's `c.Bind()` and the parameter location they map to (checked in this order).
+var parameterTagLocations = []struct {
+	tag string
+	in  string
+}{
+	{"params", openapi3.ParameterInPath},
+	{"uri", openapi3.ParameterInPath},
+	{"query", openapi3.ParameterInQuery},
+	{"header", openapi3.ParameterInHeader},
+	{"reqHeader", openapi3.ParameterInHeader},
+	{"cookie", openapi3.ParameterInCookie},
+}
+
+// CreateParametersFromStruct emits one parameter per field of the struct type which has a `query`, `params` (`uri`),
+// `header` (`reqHeader`) or `cookie` tag. Fields without any of these tags are skipped, embedded structs get flattened.
+// The schema of each parameter is generated the same way as the properties of a schema (types, enums, `validate` tags, ...).
+func (g *Generator) CreateParametersFromStruct(t reflect.Type) Parameters {
+	parameters := Parameters{}
+	if t == nil {
+		return parameters
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return parameters
+	}
+
+	parentName := g.schemaNamingStrategy()(t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("swaggerignore") == "true" {
+			continue
+		}
+
+		if field.Anonymous {
+			fType := field.Type
+			for fType.Kind() == reflect.Pointer {
+				fType = fType.Elem()
+			}
+			if fType.Kind() == reflect.Struct {
+				parameters = append(parameters, g.CreateParametersFromStruct(fType)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		for _, location := range parameterTagLocations {
+			tag, exists := field.Tag.Lookup(location.tag)
+			name := strings.Split(tag, ",")[0]
+			if !exists || name == "" || name == "-" {
+				continue
+			}
+
+			parent := &Schema{}
+			schema := g.generateFieldSchema(t, field, name, parent, parentName)
+			if schema == nil {
+				break
+			}
+			parameter := &Parameter{Name: name, In: location.in, Schema: schema}
+			if location.in == openapi3.ParameterInPath || slices.Contains(parent.Required, name) {
+				parameter.Required = true
+			}
+			if schema.Value != nil && schema.Value.Description != "" {
+				parameter.Description = strings.TrimSpace(schema.Value.Description)
+			}
+			if schema.Value != nil {
+				parameter.Deprecated = schema.Value.Deprecated
+			}
+			parameters = append(parameters, &ParameterRef{Value: parameter})
+			break
+		}
+	}
+	return parameters
+}
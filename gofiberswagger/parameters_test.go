@@ -0,0 +1,76 @@
+package gofiberswagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParametersPagination struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"max=100"`
+}
+
+type ParametersFilters struct {
+	ParametersPagination
+	Id         int      `params:"id"`
+	Search     string   `query:"search" validate:"required"`
+	Status     TestEnum `query:"status"`
+	Tags       []string `query:"tags"`
+	RequestId  string   `reqHeader:"X-Request-ID"`
+	Token      string   `header:"Authorization" validate:"required"`
+	Session    string   `cookie:"session"`
+	Ignored    string   `query:"ignored" swaggerignore:"true"`
+	Body       string   `json:"body"`
+	unexported string   `query:"unexported"`
+}
+
+func TestNewParametersFromStruct(t *testing.T) {
+	t.Parallel()
+
+	params := NewParametersFromStruct[ParametersFilters]()
+	assert.Len(t, params, 9)
+
+	page := params.GetByInAndName("query", "page")
+	assert.NotNil(t, page)
+	assert.Equal(t, "integer", (*page.Schema.Value.Type)[0])
+	assert.Equal(t, float64(1), *page.Schema.Value.Min)
+	assert.False(t, page.Required)
+
+	limit := params.GetByInAndName("query", "limit")
+	assert.NotNil(t, limit)
+	assert.Equal(t, float64(100), *limit.Schema.Value.Max)
+
+	id := params.GetByInAndName("path", "id")
+	assert.NotNil(t, id)
+	assert.True(t, id.Required)
+	assert.Equal(t, "integer", (*id.Schema.Value.Type)[0])
+
+	search := params.GetByInAndName("query", "search")
+	assert.NotNil(t, search)
+	assert.True(t, search.Required)
+
+	status := params.GetByInAndName("query", "status")
+	assert.NotNil(t, status)
+	assert.Len(t, status.Schema.Value.Enum, 2)
+
+	tags := params.GetByInAndName("query", "tags")
+	assert.NotNil(t, tags)
+	assert.Equal(t, "array", (*tags.Schema.Value.Type)[0])
+
+	assert.NotNil(t, params.GetByInAndName("header", "X-Request-ID"))
+	token := params.GetByInAndName("header", "Authorization")
+	assert.NotNil(t, token)
+	assert.True(t, token.Required)
+	assert.NotNil(t, params.GetByInAndName("cookie", "session"))
+
+	assert.Nil(t, params.GetByInAndName("query", "ignored"))
+	assert.Nil(t, params.GetByInAndName("query", "unexported"))
+}
+
+func TestNewParametersFromStruct_NotAStruct(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, NewParametersFromStruct[string]())
+	assert.Empty(t, NewParametersFromStruct[any]())
+}
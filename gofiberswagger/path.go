@@ -0,0 +1,312 @@
+package gofiberswagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+/// ------------------------------------------------------------------------------- ///
+/// Translation of fiber route paths (params, constraints, wildcards) into openapi. ///
+/// ------------------------------------------------------------------------------- ///
+
+type routePathSegment struct {
+	literal string
+	param   *routePathParam
+}
+
+type routePathParam struct {
+	name        string
+	optional    bool
+	greedy      bool
+	constraints []routePathConstraint
+}
+
+type routePathConstraint struct {
+	name string
+	data []string
+}
+
+type routePathVariant struct {
+	path   string
+	params map[string]bool
+}
+
+// parseRoutePath splits a fiber route path (eg. "/users/:id<int;min(1)>/:name?/*") into literals and parameters.
+// Greedy parameters are named the same way fiber names them, "*1", "*2", "+1", ...
+func parseRoutePath(path string) []routePathSegment {
+	segments := []routePathSegment{}
+	literal := strings.Builder{}
+	greedy_counts := map[byte]int{}
+
+	flush_literal := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, routePathSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(path); i++ {
+		char := path[i]
+		switch char {
+		case '\\':
+			if i+1 < len(path) {
+				i++
+				literal.WriteByte(path[i])
+			}
+		case '*', '+':
+			flush_literal()
+			greedy_counts[char]++
+			segments = append(segments, routePathSegment{param: &routePathParam{
+				name:   string(char) + strconv.Itoa(greedy_counts[char]),
+				greedy: true,
+			}})
+		case ':':
+			end := i + 1
+			for end < len(path) && !strings.ContainsRune("?:\\/-.<", rune(path[end])) {
+				end++
+			}
+			if end == i+1 {
+				literal.WriteByte(char)
+				continue
+			}
+			flush_literal()
+			param := &routePathParam{name: path[i+1 : end]}
+			if end < len(path) && path[end] == '<' {
+				constraints_end := findConstraintsEnd(path, end)
+				param.constraints = parseRouteConstraints(path[end+1 : constraints_end])
+				end = constraints_end + 1
+			}
+			if end < len(path) && path[end] == '?' {
+				param.optional = true
+				end++
+			}
+			segments = append(segments, routePathSegment{param: param})
+			i = end - 1
+		default:
+			literal.WriteByte(char)
+		}
+	}
+	flush_literal()
+
+	return segments
+}
+
+func findConstraintsEnd(path string, start int) int {
+	depth := 0
+	for i := start + 1; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '>':
+			if depth <= 0 {
+				return i
+			}
+		}
+	}
+	return len(path) - 1
+}
+
+func parseRouteConstraints(raw string) []routePathConstraint {
+	constraints := []routePathConstraint{}
+	for _, part := range splitOutsideParentheses(raw, ';') {
+		constraint := routePathConstraint{name: part}
+		if start := strings.IndexByte(part, '('); start != -1 && strings.HasSuffix(part, ")") {
+			constraint.name = part[:start]
+			data := part[start+1 : len(part)-1]
+			if strings.EqualFold(constraint.name, fiber.ConstraintRegex) {
+				constraint.data = []string{data}
+			} else {
+				for _, d := range splitOutsideParentheses(data, ',') {
+					constraint.data = append(constraint.data, unescapeRoutePath(strings.TrimSpace(d)))
+				}
+			}
+		}
+		if constraint.name != "" {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+func splitOutsideParentheses(raw string, separator byte) []string {
+	parts := []string{}
+	depth := 0
+	last := 0
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case separator:
+			if depth == 0 {
+				parts = append(parts, raw[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, raw[last:])
+}
+
+func unescapeRoutePath(raw string) string {
+	result := strings.Builder{}
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '\\' && i+1 < len(raw) {
+			i++
+		}
+		result.WriteByte(raw[i])
+	}
+	return result.String()
+}
+
+func routePathParams(segments []routePathSegment) []*routePathParam {
+	params := []*routePathParam{}
+	for _, segment := range segments {
+		if segment.param != nil {
+			params = append(params, segment.param)
+		}
+	}
+	return params
+}
+
+// routePathVariants returns the openapi paths the route can be reached at.
+// Openapi has no concept of optional path parameters, so every combination of present / missing optional parameters
+// is emitted as its own path, starting with the one where all of them are present.
+func routePathVariants(segments []routePathSegment) []routePathVariant {
+	optional := []string{}
+	for _, param := range routePathParams(segments) {
+		if param.optional {
+			optional = append(optional, param.name)
+		}
+	}
+
+	variants := []routePathVariant{}
+	seen := map[string]bool{}
+	for mask := 0; mask < 1<<len(optional); mask++ {
+		missing := map[string]bool{}
+		for i, name := range optional {
+			if mask&(1<<i) != 0 {
+				missing[name] = true
+			}
+		}
+
+		path := strings.Builder{}
+		params := map[string]bool{}
+		for i, segment := range segments {
+			if segment.param == nil {
+				literal := segment.literal
+				// drop the slash in front of a missing parameter which occupies the whole path segment
+				if i+1 < len(segments) && segments[i+1].param != nil && missing[segments[i+1].param.name] &&
+					(i+2 >= len(segments) || strings.HasPrefix(segments[i+2].literal, "/")) {
+					literal = strings.TrimSuffix(literal, "/")
+				}
+				path.WriteString(literal)
+				continue
+			}
+			if missing[segment.param.name] {
+				continue
+			}
+			path.WriteString("{" + segment.param.name + "}")
+			params[segment.param.name] = true
+		}
+
+		variant_path := path.String()
+		if variant_path == "" {
+			variant_path = "/"
+		}
+		if !seen[variant_path] {
+			seen[variant_path] = true
+			variants = append(variants, routePathVariant{path: variant_path, params: params})
+		}
+	}
+	return variants
+}
+
+// schema translates the fiber constraints of a parameter into the closest openapi schema (string by default).
+func (param *routePathParam) schema() *Schema {
+	schema := NewStringSchema()
+	for _, constraint := range param.constraints {
+		data_float := func(i int) *float64 {
+			if i >= len(constraint.data) {
+				return nil
+			}
+			val, err := strconv.ParseFloat(constraint.data[i], 64)
+			if err != nil {
+				return nil
+			}
+			return &val
+		}
+		data_uint := func(i int) *uint64 {
+			if i >= len(constraint.data) {
+				return nil
+			}
+			val, err := strconv.ParseUint(constraint.data[i], 10, 64)
+			if err != nil {
+				return nil
+			}
+			return &val
+		}
+		ensure_numeric := func() {
+			if !schema.Type.Is("integer") && !schema.Type.Is("number") {
+				schema.Type = &Types{"integer"}
+				schema.Format = ""
+			}
+		}
+
+		switch strings.ToLower(constraint.name) {
+		case fiber.ConstraintInt:
+			schema.Type = &Types{"integer"}
+		case fiber.ConstraintBool:
+			schema.Type = &Types{"boolean"}
+		case fiber.ConstraintFloat:
+			schema.Type = &Types{"number"}
+		case fiber.ConstraintAlpha:
+			schema.Pattern = "^[a-zA-Z]+$"
+		case fiber.ConstraintGUID:
+			schema.Format = "uuid"
+		case fiber.ConstraintDatetime:
+			layout := ""
+			if len(constraint.data) > 0 {
+				layout = constraint.data[0]
+			}
+			applyDatetimeLayout(schema, layout)
+		case fiber.ConstraintMinLenLower:
+			if val := data_uint(0); val != nil {
+				schema.MinLength = *val
+			}
+		case fiber.ConstraintMaxLenLower:
+			schema.MaxLength = data_uint(0)
+		case fiber.ConstraintLen:
+			if val := data_uint(0); val != nil {
+				schema.MinLength, schema.MaxLength = *val, val
+			}
+		case fiber.ConstraintBetweenLenLower:
+			if val := data_uint(0); val != nil {
+				schema.MinLength = *val
+			}
+			schema.MaxLength = data_uint(1)
+		case fiber.ConstraintMin:
+			ensure_numeric()
+			schema.Min = data_float(0)
+		case fiber.ConstraintMax:
+			ensure_numeric()
+			schema.Max = data_float(0)
+		case fiber.ConstraintRange:
+			ensure_numeric()
+			schema.Min, schema.Max = data_float(0), data_float(1)
+		case fiber.ConstraintRegex:
+			if len(constraint.data) > 0 {
+				schema.Pattern = constraint.data[0]
+			}
+		}
+	}
+	return schema
+}
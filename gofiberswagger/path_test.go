@@ -0,0 +1,124 @@
+package gofiberswagger
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutePathVariants(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path     string
+		expected []string
+	}{
+		{"/", []string{"/"}},
+		{"/users/:id", []string{"/users/{id}"}},
+		{"/users/:id<int;min(1)>", []string{"/users/{id}"}},
+		{"/files/:name?", []string{"/files/{name}", "/files"}},
+		{"/files/:name?/raw", []string{"/files/{name}/raw", "/files/raw"}},
+		{"/c/:p1?/:p2?", []string{"/c/{p1}/{p2}", "/c/{p2}", "/c/{p1}", "/c"}},
+		{"/b/:name.:ext", []string{"/b/{name}.{ext}"}},
+		{"/test/*", []string{"/test/{*1}"}},
+		{"/test/*/+/*", []string{"/test/{*1}/{+1}/{*2}"}},
+		{"/escaped\\:colon/:id", []string{"/escaped:colon/{id}"}},
+		{"/a/:date<datetime(2006\\-01\\-02)>/:x<regex(\\d{3})>", []string{"/a/{date}/{x}"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+			paths := []string{}
+			for _, variant := range routePathVariants(parseRoutePath(tc.path)) {
+				paths = append(paths, variant.path)
+			}
+			assert.Equal(t, tc.expected, paths)
+		})
+	}
+}
+
+func TestRoutePathParamSchema(t *testing.T) {
+	t.Parallel()
+
+	schemaOf := func(path string) *Schema {
+		params := routePathParams(parseRoutePath(path))
+		assert.Len(t, params, 1)
+		return params[0].schema()
+	}
+	ptr := func(v float64) *float64 { return &v }
+	uptr := func(v uint64) *uint64 { return &v }
+
+	s := schemaOf("/:id")
+	assert.Equal(t, "string", (*s.Type)[0])
+
+	s = schemaOf("/:id<int;min(1)>")
+	assert.Equal(t, "integer", (*s.Type)[0])
+	assert.Equal(t, ptr(1), s.Min)
+
+	s = schemaOf("/:id<range(1,10)>")
+	assert.Equal(t, "integer", (*s.Type)[0])
+	assert.Equal(t, ptr(1), s.Min)
+	assert.Equal(t, ptr(10), s.Max)
+
+	s = schemaOf("/:id<float;max(2.5)>")
+	assert.Equal(t, "number", (*s.Type)[0])
+	assert.Equal(t, ptr(2.5), s.Max)
+
+	s = schemaOf("/:id<bool>")
+	assert.Equal(t, "boolean", (*s.Type)[0])
+
+	s = schemaOf("/:id<guid>")
+	assert.Equal(t, "uuid", s.Format)
+
+	s = schemaOf("/:name<alpha;minLen(2);maxLen(5)>")
+	assert.Equal(t, "^[a-zA-Z]+$", s.Pattern)
+	assert.Equal(t, uint64(2), s.MinLength)
+	assert.Equal(t, uptr(5), s.MaxLength)
+
+	s = schemaOf("/:name<len(4)>")
+	assert.Equal(t, uint64(4), s.MinLength)
+	assert.Equal(t, uptr(4), s.MaxLength)
+
+	s = schemaOf("/:name<betweenLen(1,3)>")
+	assert.Equal(t, uint64(1), s.MinLength)
+	assert.Equal(t, uptr(3), s.MaxLength)
+
+	s = schemaOf("/:x<regex(\\d{3})>")
+	assert.Equal(t, "\\d{3}", s.Pattern)
+
+	s = schemaOf("/:date<datetime(2006\\-01\\-02)>")
+	assert.Equal(t, "date", s.Format)
+}
+
+func TestRegister_RouteConstraints(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	handler := func(c fiber.Ctx) error {
+		return c.SendStatus(200)
+	}
+	app.Get("/users/:id<int;min(1)>", handler)
+	app.Get("/files/:name?", handler)
+
+	config := Config{}
+	config.Swagger = swaggerConfigDefault(config.Swagger)
+	assert.NoError(t, generator.register(app, config))
+
+	users := config.Swagger.Paths.Find("/users/{id}")
+	assert.NotNil(t, users)
+	id := users.Get.Parameters.GetByInAndName("path", "id")
+	assert.NotNil(t, id)
+	assert.Equal(t, "integer", (*id.Schema.Value.Type)[0])
+	assert.Equal(t, float64(1), *id.Schema.Value.Min)
+
+	with_name := config.Swagger.Paths.Find("/files/{name}")
+	assert.NotNil(t, with_name)
+	assert.NotNil(t, with_name.Get.Parameters.GetByInAndName("path", "name"))
+
+	without_name := config.Swagger.Paths.Find("/files")
+	assert.NotNil(t, without_name)
+	assert.Nil(t, without_name.Get.Parameters.GetByInAndName("path", "name"))
+}
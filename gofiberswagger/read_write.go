@@ -0,0 +1,275 @@
+package gofiberswagger
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+/// ----------------------------------------------------------------------------------------------- ///
+/// Separate Input / Output components for types with readOnly / writeOnly fields (see Config) ///
+/// ----------------------------------------------------------------------------------------------- ///
+
+const componentsSchemasPrefix = "#/components/schemas/"
+
+// splitReadWriteSchemas returns a copy of the document in which every component with readOnly / writeOnly properties,
+// used by both request bodies and responses, is replaced by an Input (without the readOnly properties)
+// and an Output (without the writeOnly properties) variant, eg. "User" becomes "UserInput" and "UserOutput".
+// Components referenced by the split ones get split as well, the original component is kept only if something else still references it.
+func splitReadWriteSchemas(doc openapi3.T) (*openapi3.T, error) {
+	split, err := copyDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	if split.Components == nil || split.Paths == nil {
+		return split, nil
+	}
+	components := split.Components.Schemas
+
+	requests, responses := map[string]bool{}, map[string]bool{}
+	for _, operation := range documentOperations(split) {
+		for _, ref := range requestBodySchemaRefs(operation) {
+			collectComponentRefs(components, ref, requests)
+		}
+		for _, ref := range responseSchemaRefs(operation) {
+			collectComponentRefs(components, ref, responses)
+		}
+	}
+
+	names := map[string]bool{}
+	needs_split := map[string]bool{}
+	for _, name := range sortedKeys(components) {
+		if requests[name] && responses[name] && hasReadWriteProperties(components, name, needs_split, map[string]bool{}) {
+			names[name] = true
+		}
+	}
+	if len(names) == 0 {
+		return split, nil
+	}
+
+	for _, name := range sortedKeys(names) {
+		for _, suffix := range []string{"Input", "Output"} {
+			if components[name+suffix] != nil {
+				return nil, errors.New("gofiber-swagger: unable to split the readOnly / writeOnly properties of \"" + name + "\", the component \"" + name + suffix + "\" already exists")
+			}
+		}
+	}
+	for _, name := range sortedKeys(names) {
+		input, err := copySchemaRef(components[name])
+		if err != nil {
+			return nil, err
+		}
+		output, err := copySchemaRef(components[name])
+		if err != nil {
+			return nil, err
+		}
+		components[name+"Input"] = rewriteReadWriteSchema(input, names, "Input", func(schema *Schema) bool { return schema.ReadOnly })
+		components[name+"Output"] = rewriteReadWriteSchema(output, names, "Output", func(schema *Schema) bool { return schema.WriteOnly })
+	}
+
+	for _, operation := range documentOperations(split) {
+		for _, ref := range requestBodySchemaRefs(operation) {
+			rewriteComponentRefs(ref, names, "Input")
+		}
+		for _, ref := range responseSchemaRefs(operation) {
+			rewriteComponentRefs(ref, names, "Output")
+		}
+	}
+
+	// the originals are removed, unless something else (parameters, other components, ...) still references them
+	referenced := map[string]bool{}
+	for _, operation := range documentOperations(split) {
+		for _, ref := range operationSchemaRefs(operation) {
+			collectComponentRefs(components, ref, referenced)
+		}
+	}
+	for name, component := range components {
+		if !names[name] {
+			collectComponentRefs(components, &SchemaRef{Value: component.Value}, referenced)
+		}
+	}
+	for name := range names {
+		if !referenced[name] {
+			delete(components, name)
+		}
+	}
+	return split, nil
+}
+
+// collectComponentRefs collects the names of every component referenced by the schema, following the references.
+func collectComponentRefs(components Schemas, ref *SchemaRef, collected map[string]bool) {
+	if ref == nil {
+		return
+	}
+	if name, ok := strings.CutPrefix(ref.Ref, componentsSchemasPrefix); ok {
+		if collected[name] {
+			return
+		}
+		collected[name] = true
+		collectComponentRefs(components, components[name], collected)
+		return
+	}
+	if ref.Value == nil {
+		return
+	}
+	for _, child := range schemaChildren(ref.Value) {
+		collectComponentRefs(components, child, collected)
+	}
+}
+
+// hasReadWriteProperties reports whether the component (or a component referenced by it) has readOnly / writeOnly properties.
+func hasReadWriteProperties(components Schemas, name string, cache map[string]bool, visiting map[string]bool) bool {
+	if result, ok := cache[name]; ok {
+		return result
+	}
+	if visiting[name] || components[name] == nil {
+		return false
+	}
+	visiting[name] = true
+
+	result := false
+	var check func(ref *SchemaRef)
+	check = func(ref *SchemaRef) {
+		if ref == nil || result {
+			return
+		}
+		if referenced, ok := strings.CutPrefix(ref.Ref, componentsSchemasPrefix); ok {
+			result = hasReadWriteProperties(components, referenced, cache, visiting)
+			return
+		}
+		if ref.Value == nil {
+			return
+		}
+		for _, property := range ref.Value.Properties {
+			if property.Ref == "" && property.Value != nil && (property.Value.ReadOnly || property.Value.WriteOnly) {
+				result = true
+				return
+			}
+		}
+		for _, child := range schemaChildren(ref.Value) {
+			check(child)
+		}
+	}
+	check(&SchemaRef{Value: components[name].Value})
+
+	cache[name] = result
+	return result
+}
+
+// rewriteReadWriteSchema removes the properties matching remove (and their required entries) from the schema
+// and points the references of the split components to their variants.
+func rewriteReadWriteSchema(ref *SchemaRef, names map[string]bool, suffix string, remove func(schema *Schema) bool) *SchemaRef {
+	var rewrite func(ref *SchemaRef)
+	rewrite = func(ref *SchemaRef) {
+		if ref == nil || ref.Value == nil || ref.Ref != "" {
+			return
+		}
+		schema := ref.Value
+		for _, name := range sortedKeys(schema.Properties) {
+			property := schema.Properties[name]
+			if property.Ref == "" && property.Value != nil && remove(property.Value) {
+				delete(schema.Properties, name)
+				schema.Required = slices.DeleteFunc(schema.Required, func(required string) bool { return required == name })
+			}
+		}
+		for _, child := range schemaChildren(schema) {
+			rewrite(child)
+		}
+	}
+	rewrite(ref)
+	rewriteComponentRefs(ref, names, suffix)
+	if ref.Value != nil && ref.Value.Title != "" {
+		ref.Value.Title += suffix
+	}
+	return ref
+}
+
+// rewriteComponentRefs points the (inline) references of the split components to their variants.
+func rewriteComponentRefs(ref *SchemaRef, names map[string]bool, suffix string) {
+	if ref == nil {
+		return
+	}
+	if name, ok := strings.CutPrefix(ref.Ref, componentsSchemasPrefix); ok {
+		if names[name] {
+			ref.Ref = componentsSchemasPrefix + name + suffix
+		}
+		return
+	}
+	if ref.Value == nil {
+		return
+	}
+	for _, child := range schemaChildren(ref.Value) {
+		rewriteComponentRefs(child, names, suffix)
+	}
+}
+
+func copySchemaRef(ref *SchemaRef) (*SchemaRef, error) {
+	data, err := ref.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	copied := &SchemaRef{}
+	if err := copied.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+func documentOperations(doc *openapi3.T) []*RouteInfo {
+	operations := []*RouteInfo{}
+	for _, path := range doc.Paths.InMatchingOrder() {
+		path_item := doc.Paths.Value(path)
+		for _, method := range sortedKeys(path_item.Operations()) {
+			operations = append(operations, path_item.Operations()[method])
+		}
+	}
+	return operations
+}
+
+func requestBodySchemaRefs(operation *RouteInfo) []*SchemaRef {
+	refs := []*SchemaRef{}
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		for _, media_type := range operation.RequestBody.Value.Content {
+			refs = append(refs, media_type.Schema)
+		}
+	}
+	return refs
+}
+
+func responseSchemaRefs(operation *RouteInfo) []*SchemaRef {
+	refs := []*SchemaRef{}
+	if operation.Responses == nil {
+		return refs
+	}
+	for _, response := range operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		for _, media_type := range response.Value.Content {
+			refs = append(refs, media_type.Schema)
+		}
+		for _, header := range response.Value.Headers {
+			if header.Value != nil {
+				refs = append(refs, header.Value.Schema)
+			}
+		}
+	}
+	return refs
+}
+
+// operationSchemaRefs returns the schemas of the parameters, the request body and the responses of the operation.
+func operationSchemaRefs(operation *RouteInfo) []*SchemaRef {
+	refs := append(requestBodySchemaRefs(operation), responseSchemaRefs(operation)...)
+	for _, parameter := range operation.Parameters {
+		if parameter.Value == nil {
+			continue
+		}
+		refs = append(refs, parameter.Value.Schema)
+		for _, media_type := range parameter.Value.Content {
+			refs = append(refs, media_type.Schema)
+		}
+	}
+	return refs
+}
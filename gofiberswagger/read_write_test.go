@@ -0,0 +1,120 @@
+package gofiberswagger
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type ReadWriteUser struct {
+	Id        int                `json:"id" swagger:"readonly" validate:"required"`
+	CreatedAt time.Time          `json:"created_at" swagger:"readonly"`
+	Name      string             `json:"name" validate:"required"`
+	Password  string             `json:"password" swagger:"writeonly"`
+	Profile   ReadWriteProfile   `json:"profile"`
+	Settings  ReadWriteSettings  `json:"settings"`
+	Audit     *ReadWriteAuditLog `json:"audit"`
+	Owner     ReadWriteOwner     `json:"owner" swagger:"readonly"`
+}
+
+type ReadWriteOwner struct {
+	Name string `json:"name"`
+}
+
+type ReadWriteProfile struct {
+	Bio   string `json:"bio"`
+	Token string `json:"token" swagger:"writeonly"`
+}
+
+type ReadWriteSettings struct {
+	Theme string `json:"theme"`
+}
+
+type ReadWriteAuditLog struct {
+	Entries []string `json:"entries" swagger:"readonly"`
+}
+
+func generateReadWriteDocument(t *testing.T, split bool, version OpenAPIVersion) map[string]any {
+	app := fiber.New()
+	generator := NewGenerator(Config{SchemaNamingStrategy: ShortSchemaNames})
+	router := generator.NewRouter(app)
+	router.Post("/users", &RouteInfo{
+		RequestBody: &RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(generator.CreateSchema(reflect.TypeFor[ReadWriteUser]()))},
+		Responses:   NewResponsesRaw(map[string]*ResponseRef{"200": {Value: openapi3.NewResponse().WithDescription("OK").WithJSONSchemaRef(generator.CreateSchema(reflect.TypeFor[ReadWriteUser]()))}}),
+	}, func(c fiber.Ctx) error { return nil })
+	router.Get("/audit", &RouteInfo{
+		Responses: NewResponsesRaw(map[string]*ResponseRef{"200": {Value: openapi3.NewResponse().WithDescription("OK").WithJSONSchemaRef(generator.CreateSchema(reflect.TypeFor[ReadWriteAuditLog]()))}}),
+	}, func(c fiber.Ctx) error { return nil })
+
+	_, as_map := generateTestDocument(t, app, generator, Config{SplitReadWriteSchemas: split, OpenAPIVersion: version})
+	return as_map["components"].(map[string]any)["schemas"].(map[string]any)
+}
+
+func TestSchema_ReadWriteTags(t *testing.T) {
+	t.Parallel()
+
+	schema := NewGenerator(Config{}).CreateSchema(reflect.TypeFor[ReadWriteUser]())
+	assert.True(t, schema.Value.Properties["id"].Value.ReadOnly)
+	assert.True(t, schema.Value.Properties["created_at"].Value.ReadOnly)
+	assert.True(t, schema.Value.Properties["password"].Value.WriteOnly)
+	assert.False(t, schema.Value.Properties["name"].Value.ReadOnly)
+
+	// the referenced component gets wrapped, since readOnly next to its $ref would be ignored
+	owner := schema.Value.Properties["owner"]
+	assert.Empty(t, owner.Ref)
+	assert.True(t, owner.Value.ReadOnly)
+	assert.Equal(t, "#/components/schemas/"+PackageQualifiedSchemaNames(reflect.TypeFor[ReadWriteOwner]()), owner.Value.AllOf[0].Ref)
+}
+
+func TestSplitReadWriteSchemas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		components := generateReadWriteDocument(t, false, OpenAPIVersion31)
+		assert.Contains(t, components, "ReadWriteUser")
+		assert.NotContains(t, components, "ReadWriteUserInput")
+	})
+
+	for _, version := range []OpenAPIVersion{OpenAPIVersion30, OpenAPIVersion31} {
+		t.Run("enabled "+string(version), func(t *testing.T) {
+			t.Parallel()
+
+			components := generateReadWriteDocument(t, true, version)
+			assert.NotContains(t, components, "ReadWriteUser")
+			assert.NotContains(t, components, "ReadWriteProfile")
+			// no readOnly / writeOnly properties, nothing to split
+			assert.Contains(t, components, "ReadWriteSettings")
+			assert.NotContains(t, components, "ReadWriteSettingsInput")
+			// responses outside of the split schemas use the output variant as well
+			assert.NotContains(t, components, "ReadWriteAuditLog")
+			assert.Contains(t, components, "ReadWriteAuditLogInput")
+			assert.Contains(t, components, "ReadWriteAuditLogOutput")
+
+			input := components["ReadWriteUserInput"].(map[string]any)
+			input_properties := input["properties"].(map[string]any)
+			assert.NotContains(t, input_properties, "id")
+			assert.NotContains(t, input_properties, "created_at")
+			assert.NotContains(t, input_properties, "owner")
+			assert.Contains(t, input_properties, "password")
+			assert.Equal(t, []any{"name"}, input["required"])
+			assert.Equal(t, "#/components/schemas/ReadWriteProfileInput", input_properties["profile"].(map[string]any)["$ref"])
+			assert.Equal(t, "#/components/schemas/ReadWriteSettings", input_properties["settings"].(map[string]any)["$ref"])
+
+			output := components["ReadWriteUserOutput"].(map[string]any)
+			output_properties := output["properties"].(map[string]any)
+			assert.Contains(t, output_properties, "id")
+			assert.Equal(t, true, output_properties["owner"].(map[string]any)["readOnly"])
+			// the nested component has nothing to split
+			assert.Contains(t, components, "ReadWriteOwner")
+			assert.NotContains(t, output_properties, "password")
+			assert.Equal(t, "#/components/schemas/ReadWriteProfileOutput", output_properties["profile"].(map[string]any)["$ref"])
+			assert.NotContains(t, components["ReadWriteProfileOutput"].(map[string]any)["properties"], "token")
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package gofiberswagger
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gofiber/fiber/v3"
+)
+
+type RequestValidatorConfig struct {
+	// Next defines a function to skip this middleware when it returns true.
+	// default: nil
+	Next func(c fiber.Ctx) bool
+
+	// Skips the validation of the request body.
+	// default: false
+	ExcludeRequestBody bool
+
+	// Called when the request doesn't match the generated document.
+	// default: responds with 400 and {"status": "error", "msg": "...", "errors": [...]}
+	ErrorHandler func(c fiber.Ctx, validation_errors []ValidationError) error
+}
+
+// ValidationError describes a single value of the request (or response) that doesn't match the generated document.
+type ValidationError struct {
+	// One of "path", "query", "header", "cookie", "body" or "response" (eg. for an undocumented status code)
+	In string `json:"in"`
+	// Name of the parameter, or a "/" separated path to the invalid property of the body (empty for the body itself)
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var DefaultRequestValidatorConfig = RequestValidatorConfig{
+	Next:               nil,
+	ExcludeRequestBody: false,
+	ErrorHandler:       defaultRequestValidationErrorHandler,
+}
+
+func requestValidatorConfigDefault(config ...RequestValidatorConfig) RequestValidatorConfig {
+	if len(config) < 1 {
+		return DefaultRequestValidatorConfig
+	}
+
+	cfg := config[0]
+	if cfg.ErrorHandler == nil {
+		cfg.ErrorHandler = DefaultRequestValidatorConfig.ErrorHandler
+	}
+	return cfg
+}
+
+func defaultRequestValidationErrorHandler(c fiber.Ctx, validation_errors []ValidationError) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "msg": "Invalid request", "errors": validation_errors})
+}
+
+// NewRequestValidator creates a middleware which validates the path, query, header and cookie parameters
+// and the request body against the document generated by Register.
+// Routes which are not documented, or requests made before Register was called, are passed through.
+func NewRequestValidator(config ...RequestValidatorConfig) fiber.Handler {
+	return defaultGenerator.NewRequestValidator(config...)
+}
+
+func (g *Generator) NewRequestValidator(config ...RequestValidatorConfig) fiber.Handler {
+	cfg := requestValidatorConfigDefault(config...)
+
+	return func(c fiber.Ctx) error {
+		if cfg.Next != nil && cfg.Next(c) {
+			return c.Next()
+		}
+
+		snapshot := g.getDocumentSnapshot()
+		if snapshot == nil {
+			return c.Next()
+		}
+		route, path_params, err := snapshot.findRoute(c.Method(), c.Path())
+		if err != nil {
+			return err
+		}
+		if route == nil {
+			return c.Next()
+		}
+
+		request, err := convertToHttpRequest(c)
+		if err != nil {
+			return err
+		}
+		options := &openapi3filter.Options{
+			ExcludeRequestBody:  cfg.ExcludeRequestBody || !hasRegisteredBodyDecoder(c.Get(fiber.HeaderContentType)),
+			MultiError:          true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			SkipSettingDefaults: true,
+		}
+		err = openapi3filter.ValidateRequest(c.Context(), &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: path_params,
+			Route:      route,
+			Options:    options,
+		})
+		if err != nil {
+			return cfg.ErrorHandler(c, toValidationErrors(withBodyErrorPointers(err, c.Body()), "", ""))
+		}
+		return c.Next()
+	}
+}
+
+func convertToHttpRequest(c fiber.Ctx) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(c.Context(), c.Method(), c.OriginalURL(), bytes.NewReader(c.Body()))
+	if err != nil {
+		return nil, errors.Join(errors.New("gofiber-swagger: unable to convert the request for validation -> "), err)
+	}
+	for name, values := range c.GetReqHeaders() {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+	return request, nil
+}
+
+// Bodies without a registered decoder (eg. application/xml) can't be validated, so they're skipped instead of rejected.
+func hasRegisteredBodyDecoder(content_type string) bool {
+	if content_type == "" {
+		return true
+	}
+	media_type, _, err := mime.ParseMediaType(content_type)
+	if err != nil {
+		return false
+	}
+	return openapi3filter.RegisteredBodyDecoder(media_type) != nil
+}
+
+// withBodyErrorPointers replaces the body errors of the json schema 2020 validator (used by kin-openapi for 3.1 documents),
+// which report their location only inside of the message, with the errors of the built-in validator, which report it as a JSONPointer.
+// The original errors are kept when the built-in validator finds nothing, eg. for the 3.1-only keywords it doesn't support.
+// Errors of nullable components get their causes added (see withNullableCauses).
+func withBodyErrorPointers(err error, body []byte) error {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		result := openapi3.MultiError{}
+		for _, inner := range e {
+			result = append(result, withBodyErrorPointers(inner, body))
+		}
+		return result
+	case *openapi3filter.RequestError:
+		if e.RequestBody != nil && e.Input != nil && hasSchemaErrorWithoutPointer(e.Err) {
+			revalidated := revalidateBody(e.RequestBody.Content, e.Input.Request.Header, body, openapi3.VisitAsRequest())
+			if revalidated != nil {
+				copied := *e
+				copied.Err = revalidated
+				return &copied
+			}
+		} else if e.RequestBody != nil {
+			copied := *e
+			copied.Err = withNullableCauses(e.Err, []openapi3.SchemaValidationOption{openapi3.VisitAsRequest(), openapi3.MultiErrors()})
+			return &copied
+		}
+	case *openapi3filter.ResponseError:
+		if e.Input != nil && e.Input.RequestValidationInput != nil && hasSchemaErrorWithoutPointer(e.Err) {
+			response := e.Input.RequestValidationInput.Route.Operation.Responses.Status(e.Input.Status)
+			if response == nil {
+				response = e.Input.RequestValidationInput.Route.Operation.Responses.Default()
+			}
+			if response != nil && response.Value != nil {
+				header := http.Header{fiber.HeaderContentType: {e.Input.Header.Get(fiber.HeaderContentType)}}
+				if revalidated := revalidateBody(response.Value.Content, header, body, openapi3.VisitAsResponse()); revalidated != nil {
+					copied := *e
+					copied.Err = revalidated
+					return &copied
+				}
+			}
+		} else if e.Input != nil {
+			copied := *e
+			copied.Err = withNullableCauses(e.Err, []openapi3.SchemaValidationOption{openapi3.VisitAsResponse(), openapi3.MultiErrors()})
+			return &copied
+		}
+	}
+	return err
+}
+
+func hasSchemaErrorWithoutPointer(err error) bool {
+	var schema_error *openapi3.SchemaError
+	return errors.As(err, &schema_error) && len(schema_error.JSONPointer()) == 0
+}
+
+// revalidateBody decodes the body the same way openapi3filter does and validates it using the built-in validator.
+func revalidateBody(content openapi3.Content, header http.Header, body []byte, opts ...openapi3.SchemaValidationOption) error {
+	media_type, _, err := mime.ParseMediaType(header.Get(fiber.HeaderContentType))
+	if err != nil {
+		return nil
+	}
+	media := content.Get(media_type)
+	decoder := openapi3filter.RegisteredBodyDecoder(media_type)
+	if media == nil || media.Schema == nil || media.Schema.Value == nil || decoder == nil {
+		return nil
+	}
+	value, err := decoder(bytes.NewReader(body), header, media.Schema, func(string) *openapi3.Encoding { return nil })
+	if err != nil {
+		return nil
+	}
+	opts = append(opts, openapi3.MultiErrors())
+	return withNullableCauses(media.Schema.Value.VisitJSON(value, opts...), opts)
+}
+
+// nestedBodyError are the errors of a value nested in the body at pointer.
+type nestedBodyError struct {
+	pointer []string
+	err     error
+}
+
+func (e *nestedBodyError) Error() string { return e.err.Error() }
+func (e *nestedBodyError) Unwrap() error { return e.err }
+
+// withNullableCauses adds the causes to the errors of nullable components, documented as `anyOf: [{$ref}, {type: null}]` in 3.1
+// (see wrapRefSiblings), which the built-in validator reports without them, by validating the value against the component again.
+func withNullableCauses(err error, opts []openapi3.SchemaValidationOption) error {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		result := openapi3.MultiError{}
+		for _, inner := range e {
+			result = append(result, withNullableCauses(inner, opts))
+		}
+		return result
+	case *openapi3.SchemaError:
+		if e.SchemaField != "anyOf" || e.Value == nil || e.Schema == nil || len(e.Schema.AnyOf) != 2 {
+			return err
+		}
+		for i, option := range e.Schema.AnyOf {
+			other := e.Schema.AnyOf[1-i]
+			if option.Value == nil || other.Value == nil || other.Value.Type == nil || !other.Value.Type.Is("null") {
+				continue
+			}
+			if causes := option.Value.VisitJSON(e.Value, opts...); causes != nil {
+				return &nestedBodyError{pointer: e.JSONPointer(), err: withNullableCauses(causes, opts)}
+			}
+		}
+	}
+	return err
+}
+
+func toValidationErrors(err error, in string, field string) []ValidationError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		result := []ValidationError{}
+		for _, inner := range e {
+			result = append(result, toValidationErrors(inner, in, field)...)
+		}
+		return result
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			in, field = e.Parameter.In, e.Parameter.Name
+		} else if e.RequestBody != nil {
+			in = "body"
+		}
+		if e.Err == nil {
+			return []ValidationError{{In: in, Field: field, Message: e.Reason}}
+		}
+		return toValidationErrors(e.Err, in, field)
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			return []ValidationError{{In: "response", Field: field, Message: e.Reason}}
+		}
+		return toValidationErrors(e.Err, "body", field)
+	case *nestedBodyError:
+		return toValidationErrors(e.err, in, strings.Trim(field+"/"+strings.Join(e.pointer, "/"), "/"))
+	case *openapi3.SchemaError:
+		if pointer := e.JSONPointer(); len(pointer) > 0 {
+			return []ValidationError{{In: in, Field: strings.Trim(field+"/"+strings.Join(pointer, "/"), "/"), Message: e.Reason}}
+		}
+		// the json schema 2020 validator reports the causes as a MultiError (see withBodyErrorPointers)
+		var causes openapi3.MultiError
+		if e.Origin != nil && errors.As(e.Origin, &causes) {
+			return toValidationErrors(causes, in, field)
+		}
+		return []ValidationError{{In: in, Field: field, Message: e.Reason}}
+	}
+
+	var schema_error *openapi3.SchemaError
+	if errors.As(err, &schema_error) {
+		return toValidationErrors(schema_error, in, field)
+	}
+	return []ValidationError{{In: in, Field: field, Message: err.Error()}}
+}
@@ -0,0 +1,146 @@
+package gofiberswagger
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type RequestValidatorAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type RequestValidatorBody struct {
+	Name    string                   `json:"name" validate:"required,min=2"`
+	Age     int                      `json:"age" validate:"min=18"`
+	Status  TestEnum                 `json:"status"`
+	Address *RequestValidatorAddress `json:"address"`
+}
+
+func setupRequestValidatorApp(t *testing.T, config ...RequestValidatorConfig) *fiber.App {
+	app := fiber.New()
+	generator := NewGenerator(Config{FilterOutAppUse: true})
+	app.Use(generator.NewRequestValidator(config...))
+
+	router := generator.NewRouter(app)
+	handler := func(c fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	}
+	router.Post("/users/:id", &RouteInfo{
+		Parameters: NewParameters(
+			INewPathParameter[int]("id"),
+			NewQueryParameterRequired("filter"),
+		),
+		RequestBody: NewRequestBodyJSONExtended[RequestValidatorBody]("", true),
+	}, handler)
+
+	// registered manually, without the router
+	app.Get("/manual", handler)
+	generator.RegisterRoute("GET", "/manual", &RouteInfo{
+		Parameters: NewParameters(NewHeaderParameterRequired("X-Request-ID")),
+	})
+
+	app.Get("/undocumented", handler)
+
+	assert.NoError(t, generator.Register(app))
+	return app
+}
+
+func doRequestValidatorRequest(t *testing.T, app *fiber.App, method string, target string, body string, headers map[string]string) (int, []ValidationError) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	raw, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	response := struct {
+		Errors []ValidationError `json:"errors"`
+	}{}
+	if resp.StatusCode == http.StatusBadRequest {
+		assert.NoError(t, json.Unmarshal(raw, &response))
+	}
+	return resp.StatusCode, response.Errors
+}
+
+func TestRequestValidator(t *testing.T) {
+	t.Parallel()
+
+	app := setupRequestValidatorApp(t)
+
+	t.Run("valid request", func(t *testing.T) {
+		status, _ := doRequestValidatorRequest(t, app, "POST", "/users/5?filter=abc", `{"name":"John","age":20,"status":"A"}`, nil)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("invalid path and missing query parameter", func(t *testing.T) {
+		status, errs := doRequestValidatorRequest(t, app, "POST", "/users/abc", `{"name":"John","age":20}`, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, fieldsOf(errs), "path:id")
+		assert.Contains(t, fieldsOf(errs), "query:filter")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		status, errs := doRequestValidatorRequest(t, app, "POST", "/users/5?filter=abc", `{"age":10,"status":"C"}`, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, fieldsOf(errs), "body:age")
+		assert.Contains(t, fieldsOf(errs), "body:status")
+		assert.Contains(t, fieldsOf(errs), "body:name")
+	})
+
+	t.Run("invalid nested body property", func(t *testing.T) {
+		status, errs := doRequestValidatorRequest(t, app, "POST", "/users/5?filter=abc", `{"name":"John","age":20,"address":{}}`, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, []string{"body:address/city"}, fieldsOf(errs))
+	})
+
+	t.Run("manually registered route", func(t *testing.T) {
+		status, errs := doRequestValidatorRequest(t, app, "GET", "/manual", "", nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, fieldsOf(errs), "header:X-Request-ID")
+
+		status, _ = doRequestValidatorRequest(t, app, "GET", "/manual", "", map[string]string{"X-Request-ID": "1"})
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("undocumented routes are passed through", func(t *testing.T) {
+		status, _ := doRequestValidatorRequest(t, app, "GET", "/undocumented", "", nil)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
+
+func TestRequestValidator_Config(t *testing.T) {
+	t.Parallel()
+
+	app := setupRequestValidatorApp(t, RequestValidatorConfig{
+		ExcludeRequestBody: true,
+		ErrorHandler: func(c fiber.Ctx, validation_errors []ValidationError) error {
+			return c.SendStatus(http.StatusUnprocessableEntity)
+		},
+	})
+
+	status, _ := doRequestValidatorRequest(t, app, "POST", "/users/5?filter=abc", `{"age":10}`, nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = doRequestValidatorRequest(t, app, "POST", "/users/abc?filter=abc", `{}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+}
+
+func fieldsOf(errs []ValidationError) []string {
+	result := []string{}
+	for _, e := range errs {
+		result = append(result, e.In+":"+e.Field)
+	}
+	return result
+}
@@ -0,0 +1,129 @@
+package gofiberswagger
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gofiber/fiber/v3"
+)
+
+type ResponseValidatorConfig struct {
+	// Next defines a function to skip this middleware when it returns true.
+	// default: nil
+	Next func(c fiber.Ctx) bool
+
+	// If set to true, invalid responses are replaced by a 500 listing the validation errors. Otherwise they're only logged.
+	// Intended for dev/test environments, where you want your integration tests to fail on undocumented responses.
+	// default: false
+	FailOnInvalidResponse bool
+
+	// Skips the validation of the response body, only the status code (and headers) get validated.
+	// default: false
+	ExcludeResponseBody bool
+
+	// Called when the response doesn't match the generated document. Overrides the default logging / FailOnInvalidResponse behaviour.
+	// default: nil
+	ErrorHandler func(c fiber.Ctx, validation_errors []ValidationError) error
+}
+
+var DefaultResponseValidatorConfig = ResponseValidatorConfig{
+	Next:                  nil,
+	FailOnInvalidResponse: false,
+	ExcludeResponseBody:   false,
+	ErrorHandler:          nil,
+}
+
+func responseValidatorConfigDefault(config ...ResponseValidatorConfig) ResponseValidatorConfig {
+	if len(config) < 1 {
+		return DefaultResponseValidatorConfig
+	}
+	return config[0]
+}
+
+// NewResponseValidator creates an opt-in middleware which validates the responses of your handlers against the document generated by Register.
+// The status code has to be declared in the operation's Responses and the body has to match the declared media-type schema.
+// Errors returned by the handlers get validated as well, using the response produced by the error handler of the app.
+// Operations without any documented responses are not validated.
+func NewResponseValidator(config ...ResponseValidatorConfig) fiber.Handler {
+	return defaultGenerator.NewResponseValidator(config...)
+}
+
+func (g *Generator) NewResponseValidator(config ...ResponseValidatorConfig) fiber.Handler {
+	cfg := responseValidatorConfigDefault(config...)
+
+	return func(c fiber.Ctx) error {
+		if cfg.Next != nil && cfg.Next(c) {
+			return c.Next()
+		}
+
+		// errors get turned into the response by the error handler first (same as the logger middleware does),
+		// so documented error responses (eg. fiber.NewError(404, ...)) get validated as well
+		if err := c.Next(); err != nil {
+			if handler_err := c.App().ErrorHandler(c, err); handler_err != nil {
+				return handler_err
+			}
+		}
+
+		snapshot := g.getDocumentSnapshot()
+		if snapshot == nil {
+			return nil
+		}
+		route, path_params, err := snapshot.findRoute(c.Method(), c.Path())
+		if err != nil {
+			return err
+		}
+		if route == nil || route.Operation.Responses.Len() == 0 {
+			return nil
+		}
+
+		request, err := convertToHttpRequest(c)
+		if err != nil {
+			return err
+		}
+		options := &openapi3filter.Options{
+			ExcludeResponseBody:   cfg.ExcludeResponseBody,
+			IncludeResponseStatus: true,
+			MultiError:            true,
+		}
+		err = openapi3filter.ValidateResponse(c.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request:    request,
+				PathParams: path_params,
+				Route:      route,
+				Options:    options,
+			},
+			Status:  c.Response().StatusCode(),
+			Header:  convertResponseHeaders(c),
+			Body:    io.NopCloser(bytes.NewReader(c.Response().Body())),
+			Options: options,
+		})
+		if err == nil {
+			return nil
+		}
+
+		validation_errors := toValidationErrors(withBodyErrorPointers(err, c.Response().Body()), "", "")
+		if cfg.ErrorHandler != nil {
+			return cfg.ErrorHandler(c, validation_errors)
+		}
+		log.Println("gofiber-swagger: response of", c.Method(), c.Path(), "doesn't match the generated document:", validation_errors)
+		if cfg.FailOnInvalidResponse {
+			c.Response().ResetBody()
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "msg": "Invalid response", "errors": validation_errors})
+		}
+		return nil
+	}
+}
+
+func convertResponseHeaders(c fiber.Ctx) http.Header {
+	header := http.Header{}
+	for name, values := range c.GetRespHeaders() {
+		for _, value := range values {
+			header.Add(name, value)
+		}
+	}
+	header.Set(fiber.HeaderContentType, string(c.Response().Header.ContentType()))
+	return header
+}
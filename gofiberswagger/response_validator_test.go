@@ -0,0 +1,115 @@
+package gofiberswagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type ResponseValidatorResponse struct {
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"min=18"`
+}
+
+type ResponseValidatorError struct {
+	Msg string `json:"msg" validate:"required"`
+}
+
+func setupResponseValidatorApp(t *testing.T, config ...ResponseValidatorConfig) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c fiber.Ctx, err error) error {
+			code := fiber.StatusInternalServerError
+			if fiber_error, ok := err.(*fiber.Error); ok {
+				code = fiber_error.Code
+			}
+			return c.Status(code).JSON(fiber.Map{"msg": err.Error()})
+		},
+	})
+	generator := NewGenerator(Config{FilterOutAppUse: true})
+	app.Use(generator.NewResponseValidator(config...))
+
+	router := generator.NewRouter(app)
+	docs := func() *RouteInfo {
+		return &RouteInfo{
+			Responses: NewResponses(
+				NewResponseInfo[ResponseValidatorResponse]("200", "OK"),
+			),
+		}
+	}
+	router.Get("/valid", docs(), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"name": "John", "age": 20})
+	})
+	router.Get("/invalid-body", docs(), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"age": 10})
+	})
+	router.Get("/invalid-status", docs(), func(c fiber.Ctx) error {
+		return c.Status(http.StatusTeapot).JSON(fiber.Map{"name": "John", "age": 20})
+	})
+	error_docs := func() *RouteInfo {
+		return &RouteInfo{
+			Responses: NewResponses(
+				NewResponseInfo[ResponseValidatorResponse]("200", "OK"),
+				NewResponseInfo[ResponseValidatorError]("404", "Not found"),
+			),
+		}
+	}
+	router.Get("/documented-error", error_docs(), func(c fiber.Ctx) error {
+		return fiber.NewError(http.StatusNotFound, "user not found")
+	})
+	router.Get("/undocumented-error", error_docs(), func(c fiber.Ctx) error {
+		return fiber.NewError(http.StatusConflict, "user already exists")
+	})
+	router.Get("/no-responses", nil, func(c fiber.Ctx) error {
+		return c.Status(http.StatusTeapot).SendString("anything")
+	})
+
+	assert.NoError(t, generator.Register(app))
+	return app
+}
+
+func TestResponseValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path           string
+		logOnlyStatus  int
+		failOnStatus   int
+		expectedErrors []string
+	}{
+		{"/valid", http.StatusOK, http.StatusOK, nil},
+		{"/invalid-body", http.StatusOK, http.StatusInternalServerError, []string{"body:name", "body:age"}},
+		{"/invalid-status", http.StatusTeapot, http.StatusInternalServerError, []string{"response:"}},
+		{"/documented-error", http.StatusNotFound, http.StatusNotFound, nil},
+		{"/undocumented-error", http.StatusConflict, http.StatusInternalServerError, []string{"response:"}},
+		{"/no-responses", http.StatusTeapot, http.StatusTeapot, nil},
+	}
+
+	var collected []ValidationError
+	collecting_app := setupResponseValidatorApp(t, ResponseValidatorConfig{
+		ErrorHandler: func(c fiber.Ctx, validation_errors []ValidationError) error {
+			collected = validation_errors
+			return nil
+		},
+	})
+	log_only_app := setupResponseValidatorApp(t)
+	fail_app := setupResponseValidatorApp(t, ResponseValidatorConfig{FailOnInvalidResponse: true})
+
+	for _, tc := range testCases {
+		collected = nil
+		resp, err := collecting_app.Test(httptest.NewRequest("GET", tc.path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.logOnlyStatus, resp.StatusCode, tc.path)
+		assert.ElementsMatch(t, tc.expectedErrors, fieldsOf(collected), tc.path)
+
+		resp, err = log_only_app.Test(httptest.NewRequest("GET", tc.path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.logOnlyStatus, resp.StatusCode, tc.path)
+
+		resp, err = fail_app.Test(httptest.NewRequest("GET", tc.path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.failOnStatus, resp.StatusCode, tc.path)
+	}
+}
@@ -2,39 +2,43 @@ package gofiberswagger
 
 import (
 	"strings"
-	"sync"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 type RouteInfo = openapi3.Operation
 
-var (
-	acquiredRoutesInfo map[string]*RouteInfo
-	mutex              = &sync.Mutex{}
-)
-
 func RegisterRoute(method string, path string, info *RouteInfo) {
-	mutex.Lock()
-	defer mutex.Unlock()
+	defaultGenerator.RegisterRoute(method, path, info)
+}
 
-	if acquiredRoutesInfo == nil {
-		acquiredRoutesInfo = make(map[string]*RouteInfo)
-	}
+func (g *Generator) RegisterRoute(method string, path string, info *RouteInfo) {
 	if info == nil {
 		info = &RouteInfo{}
 	}
-	acquiredRoutesInfo[getAcquiredRoutesInfoId(method, path)] = info
+	g.resolveRouteSchemas(info)
+
+	g.routesMutex.Lock()
+	defer g.routesMutex.Unlock()
+
+	if g.acquiredRoutesInfo == nil {
+		g.acquiredRoutesInfo = make(map[string]*RouteInfo)
+	}
+	g.acquiredRoutesInfo[getAcquiredRoutesInfoId(method, path)] = info
 }
 
 func getAcquiredRoutesInfo(method string, path string) *RouteInfo {
-	mutex.Lock()
-	defer mutex.Unlock()
+	return defaultGenerator.getAcquiredRoutesInfo(method, path)
+}
+
+func (g *Generator) getAcquiredRoutesInfo(method string, path string) *RouteInfo {
+	g.routesMutex.Lock()
+	defer g.routesMutex.Unlock()
 
-	if acquiredRoutesInfo == nil {
+	if g.acquiredRoutesInfo == nil {
 		return nil
 	}
-	return acquiredRoutesInfo[getAcquiredRoutesInfoId(method, path)]
+	return g.acquiredRoutesInfo[getAcquiredRoutesInfoId(method, path)]
 }
 
 func getAcquiredRoutesInfoId(method string, path string) string {
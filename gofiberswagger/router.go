@@ -7,61 +7,69 @@ import (
 type SwaggerRouter struct {
 	internalGroup string
 	Router        fiber.Router
+	generator     *Generator
 }
 
 func NewRouter(app *fiber.App) SwaggerRouter {
-	return NewRouterFromRouter(app.Group("/"))
+	return defaultGenerator.NewRouter(app)
 }
 func NewRouterFromRouter(r fiber.Router) SwaggerRouter {
-	return SwaggerRouter{internalGroup: "", Router: r}
+	return defaultGenerator.NewRouterFromRouter(r)
 }
 
 func (router SwaggerRouter) Get(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("GET", path, router.internalGroup, docs)
+	router.registerRoute("GET", path, docs)
 	return router.Router.Get(path, handler, handlers...)
 }
 func (router SwaggerRouter) Head(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("HEAD", path, router.internalGroup, docs)
+	router.registerRoute("HEAD", path, docs)
 	return router.Router.Head(path, handler, handlers...)
 }
 func (router SwaggerRouter) Post(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("POST", path, router.internalGroup, docs)
+	router.registerRoute("POST", path, docs)
 	return router.Router.Post(path, handler, handlers...)
 }
 func (router SwaggerRouter) Put(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("PUT", path, router.internalGroup, docs)
+	router.registerRoute("PUT", path, docs)
 	return router.Router.Put(path, handler, handlers...)
 }
 func (router SwaggerRouter) Delete(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("DELETE", path, router.internalGroup, docs)
+	router.registerRoute("DELETE", path, docs)
 	return router.Router.Delete(path, handler, handlers...)
 }
 func (router SwaggerRouter) Connect(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("CONNECT", path, router.internalGroup, docs)
+	router.registerRoute("CONNECT", path, docs)
 	return router.Router.Connect(path, handler, handlers...)
 }
 func (router SwaggerRouter) Options(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("OPTIONS", path, router.internalGroup, docs)
+	router.registerRoute("OPTIONS", path, docs)
 	return router.Router.Options(path, handler, handlers...)
 }
 func (router SwaggerRouter) Trace(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("TRACE", path, router.internalGroup, docs)
+	router.registerRoute("TRACE", path, docs)
 	return router.Router.Trace(path, handler, handlers...)
 }
 func (router SwaggerRouter) Patch(path string, docs *RouteInfo, handler any, handlers ...any) fiber.Router {
-	routerRegisterRouteInternal("PATCH", path, router.internalGroup, docs)
+	router.registerRoute("PATCH", path, docs)
 	return router.Router.Patch(path, handler, handlers...)
 }
 func (router *SwaggerRouter) Group(prefix string, handlers ...any) SwaggerRouter {
-	return SwaggerRouter{internalGroup: router.internalGroup + prefix, Router: router.Router.Group(prefix, handlers...)}
+	return SwaggerRouter{internalGroup: router.internalGroup + prefix, Router: router.Router.Group(prefix, handlers...), generator: router.generator}
 }
 
-func routerRegisterRouteInternal(method string, path string, internalGroup string, info *RouteInfo) {
+func (router SwaggerRouter) registerRoute(method string, path string, info *RouteInfo) {
 	if info == nil {
 		info = &RouteInfo{}
 	}
-	if internalGroup != "" {
-		info.Tags = append(info.Tags, internalGroup)
+	if router.internalGroup != "" {
+		info.Tags = append(info.Tags, router.internalGroup)
 	}
-	RegisterRoute(method, internalGroup+path, info)
+	router.getGenerator().RegisterRoute(method, router.internalGroup+path, info)
+}
+
+func (router SwaggerRouter) getGenerator() *Generator {
+	if router.generator == nil {
+		return defaultGenerator
+	}
+	return router.generator
 }
@@ -1,46 +1,20 @@
 package gofiberswagger
 
 import (
+	"encoding"
+	"encoding/json"
+	"log"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
-	"sync"
-
-	"github.com/google/uuid"
-)
-
-var (
-	acquiredSchemas map[string]*SchemaRef
-	schemasMutex    sync.RWMutex
 )
 
-func setToAcquiredSchemas(ref string, schema *SchemaRef) {
-	schemasMutex.Lock()
-	defer schemasMutex.Unlock()
-	if acquiredSchemas == nil {
-		acquiredSchemas = make(map[string]*SchemaRef)
-	}
-	if schema != nil {
-		acquiredSchemas[ref] = schema
-	}
-}
-
-func getFromAcquiredSchemas(ref string) *SchemaRef {
-	schemasMutex.RLock()
-	defer schemasMutex.RUnlock()
-	if acquiredSchemas == nil {
-		return nil
-	}
-	return acquiredSchemas[ref]
-}
-
+// CreateSchema generates the schema of T using the default generator (the one used by the top-level functions).
+// Use generator.CreateSchema with your own Generator, the route helpers (NewRequestBody[T], NewResponseInfo[T], ...)
+// get resolved by the Generator of the router on their own.
 func CreateSchema[T any]() *SchemaRef {
-	var t T
-	reflectType := reflect.TypeOf(t)
-	if reflectType == nil {
-		return &SchemaRef{Value: &Schema{}}
-	}
-	return generateSchema(reflectType, false)
+	return defaultGenerator.CreateSchema(reflect.TypeFor[T]())
 }
 
 func getSpecialTypeSchema(t reflect.Type) (schema *Schema, isNullable bool, handled bool) {
@@ -98,164 +72,230 @@ func getSpecialTypeSchema(t reflect.Type) (schema *Schema, isNullable bool, hand
 	return nil, false, false
 }
 
-func generateSchema(t reflect.Type, stopRecursion bool) *SchemaRef {
-	for t.Kind() == reflect.Pointer {
-		t = t.Elem()
-	}
+// generateSchema generates the schema of any go type, it's used for top-level types (request bodies, responses, ...)
+// as well as for struct fields, items and map values, so every kind gets documented the same way everywhere.
+// Named structs become components (referenced using $ref), which is also how recursive types reference themselves.
+// Returns nil for types which can't be represented (funcs, channels).
+func (g *Generator) generateSchema(t reflect.Type, stopRecursion bool, nameHint string) *SchemaRef {
+	t = derefType(t)
 
-	tName := t.Name()
-	if tName == "" {
-		genPart := uuid.NewString()
-		tName = "generated-" + genPart
+	if custom := g.getCustomTypeSchema(t); custom != nil {
+		return &SchemaRef{Value: custom}
 	}
 
-	ref := strings.ReplaceAll(strings.ReplaceAll(t.PkgPath(), "/", "_"), ".", "_") + tName
-	refPath := "#/components/schemas/" + ref
+	if special, isNullable, ok := getSpecialTypeSchema(t); ok {
+		special.Nullable = isNullable
+		return &SchemaRef{Value: special}
+	}
 
-	if cached := getFromAcquiredSchemas(ref); cached != nil {
-		if t.Kind() == reflect.Struct {
-			return &SchemaRef{Ref: refPath, Extensions: cached.Extensions, Origin: cached.Origin, Value: cached.Value}
+	if marshaled := g.getMarshalerSchema(t); marshaled != nil {
+		result := &SchemaRef{Value: marshaled}
+		// the enum values describe what the type serializes into
+		if !stopRecursion && implementsSwaggerEnum(t) {
+			g.handleEnumValues(result, getSwaggerEnumValues(t), false, t)
 		}
-		return cached
+		return result
 	}
 
-	if special, isNullable, ok := getSpecialTypeSchema(t); ok {
-		special.Nullable = isNullable
-		return &SchemaRef{Value: special}
+	if !stopRecursion && implementsSwaggerEnum(t) {
+		enumSchema := &SchemaRef{Value: getDefaultSchema(t)}
+		g.handleEnumValues(enumSchema, getSwaggerEnumValues(t), false, t)
+		return enumSchema
 	}
 
+	switch t.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	case reflect.Interface:
+		if oneOf := g.generateOneOfSchema(t); oneOf != nil {
+			return oneOf
+		}
+		return &SchemaRef{Value: &Schema{Type: &Types{"object"}}}
+	case reflect.Map:
+		return g.generateMapSchema(t, nameHint)
+	case reflect.Slice, reflect.Array:
+		// encoding/json serializes []byte as a base64 string, but [N]byte as an array of numbers
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &SchemaRef{Value: &Schema{Type: &Types{"string"}, Format: "byte"}}
+		}
+		schema := &Schema{Type: &Types{"array"}, Items: g.generateSchema(t.Elem(), false, nestedSchemaName(nameHint, "Item"))}
+		if schema.Items == nil {
+			schema.Items = &SchemaRef{Value: &Schema{}}
+		}
+		if t.Kind() == reflect.Array {
+			length := uint64(t.Len())
+			schema.MinItems, schema.MaxItems = length, &length
+		}
+		return &SchemaRef{Value: schema}
+	case reflect.Struct:
+		return g.generateStructSchema(t, nameHint)
+	}
+
+	return &SchemaRef{Value: getDefaultSchema(t)}
+}
+
+// generateStructSchema returns a $ref to the component of the struct, generating the component first if it doesn't exist yet.
+// The component is cached before its properties get generated, so recursive types resolve to a $ref of themselves.
+func (g *Generator) generateStructSchema(t reflect.Type, nameHint string) *SchemaRef {
 	schema := getDefaultSchema(t)
-	if schema.Type != nil && (*schema.Type)[0] != "object" && t.Kind() != reflect.Struct {
+	schema.Type = &Types{"object"}
+	if t.NumField() == 0 {
 		return &SchemaRef{Value: schema}
 	}
 
-	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
-		if t.Elem().Kind() == reflect.Uint8 {
-			return &SchemaRef{Value: &Schema{Type: &Types{"string"}, Format: "byte"}}
-		}
-		return &SchemaRef{Value: &Schema{Type: &Types{"array"}, Items: generateSchema(t.Elem(), false)}}
+	ref := g.schemaName(t, nameHint)
+	refPath := "#/components/schemas/" + ref
+	if cached := g.getFromAcquiredSchemas(ref); cached != nil {
+		return &SchemaRef{Ref: refPath, Extensions: cached.Extensions, Origin: cached.Origin, Value: cached.Value}
 	}
 
-	if t.Kind() == reflect.Struct {
-		if t.NumField() == 0 {
-			schema.Type = &Types{"object"}
-			return &SchemaRef{Value: schema}
-		}
+	schema.Title = schemaTypeName(t)
+	if schema.Title == "" {
+		schema.Title = ref
+	}
+	schema.Description = g.docComments().typeDoc(t)
+	g.setToAcquiredSchemas(ref, &SchemaRef{Value: schema})
 
-		if !stopRecursion && implementsSwaggerEnum(t) {
-			enumSchema := &SchemaRef{Value: getDefaultSchema(t)}
-			handleEnumValues(enumSchema, getSwaggerEnumValues(t), false, t)
-			return enumSchema
+	if generated, ok := g.getGeneratedSchema(t); ok {
+		g.applyGeneratedSchema(t, schema, generated, ref)
+		return &SchemaRef{Ref: refPath, Value: schema}
+	}
+
+	for _, field := range schemaFields(t) {
+		fieldResult := g.generateFieldSchema(field.owner, field.field, field.name, schema, ref)
+		if fieldResult == nil {
+			continue
 		}
+		schema.Properties[field.name] = fieldResult
+	}
 
-		schema.Title = tName
-		schema.Type = &Types{"object"}
-		setToAcquiredSchemas(ref, &SchemaRef{Value: &Schema{}})
+	return &SchemaRef{Ref: refPath, Value: schema}
+}
 
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			if field.Name == "XMLName" {
-				continue
-			}
+// generateMapSchema documents the map the way encoding/json serializes it. String (and encoding.TextMarshaler) keys
+// get documented using additionalProperties, integer keys (serialized as strings too) using patternProperties.
+func (g *Generator) generateMapSchema(t reflect.Type, nameHint string) *SchemaRef {
+	values := g.generateSchema(t.Elem(), false, nestedSchemaName(nameHint, "Value"))
+	if values == nil {
+		values = &SchemaRef{Value: &Schema{}}
+	}
 
-			if field.Anonymous {
-				fType := field.Type
-				for fType.Kind() == reflect.Pointer {
-					fType = fType.Elem()
-				}
-				if fType.Kind() == reflect.Struct {
-					sub := generateSchema(fType, false)
-					if sub != nil && sub.Value != nil {
-						for name, prop := range sub.Value.Properties {
-							if _, ok := schema.Properties[name]; !ok {
-								schema.Properties[name] = prop
-							}
-						}
-					}
-					continue
-				}
-			}
+	schema := &Schema{Type: &Types{"object"}}
+	key := t.Key()
+	if key.Kind() != reflect.String && !key.Implements(reflect.TypeFor[encoding.TextMarshaler]()) {
+		if pattern := integerKeyPattern(key); pattern != "" {
+			schema.PatternProperties = Schemas{pattern: values}
+			schema.PropertyNames = &SchemaRef{Value: &Schema{Type: &Types{"string"}, Pattern: pattern}}
+			return &SchemaRef{Value: schema}
+		}
+	}
 
-			jsonTag, jsonTagExists := field.Tag.Lookup("json")
-			formTag, formTagExists := field.Tag.Lookup("form")
-			queryTag, queryTagExists := field.Tag.Lookup("query")
-			xmlTag, xmlTagExists := field.Tag.Lookup("xml")
-			if jsonTagExists && strings.Split(jsonTag, ",")[0] == "-" && !formTagExists && !queryTagExists {
-				continue
-			}
-			if xmlTagExists && strings.Split(xmlTag, ",")[0] == "-" && !jsonTagExists && !formTagExists && !queryTagExists {
-				continue
-			}
+	has := true
+	schema.AdditionalProperties = AdditionalProperties{Has: &has, Schema: values}
+	return &SchemaRef{Value: schema}
+}
 
-			if field.Tag.Get("swaggerignore") == "true" {
-				continue
-			}
+func integerKeyPattern(key reflect.Type) string {
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "^-?[0-9]+$"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "^[0-9]+$"
+	}
+	return ""
+}
 
-			fieldType := field.Type
-			isNullable := false
-			for fieldType.Kind() == reflect.Pointer {
-				fieldType = fieldType.Elem()
-				isNullable = true
-			}
+// schemaFieldName returns the name of the property the field gets documented as (the first of the name tags, see fieldNameTags,
+// the go name otherwise).
+func schemaFieldName(field reflect.StructField, nameTags []string) string {
+	for _, tagName := range nameTags {
+		if parts := strings.Split(field.Tag.Get(tagName), ","); parts[0] != "" && parts[0] != "-" {
+			return parts[0]
+		}
+	}
+	return field.Name
+}
 
-			var result *SchemaRef
-			if spec, specNull, ok := getSpecialTypeSchema(fieldType); ok {
-				result = &SchemaRef{Value: spec}
-				if specNull {
-					isNullable = true
-				}
-			} else {
-				switch fieldType.Kind() {
-				case reflect.Func, reflect.Chan:
-					continue
-				case reflect.Map, reflect.Interface:
-					result = &SchemaRef{Value: &Schema{Type: &Types{"object"}}}
-					if fieldType.Kind() == reflect.Map && fieldType.Key().Kind() == reflect.String {
-						has := true
-						result.Value.AdditionalProperties = AdditionalProperties{Has: &has, Schema: generateSchema(fieldType.Elem(), false)}
-					}
-				case reflect.Slice, reflect.Array:
-					if fieldType.Elem().Kind() == reflect.Uint8 {
-						result = &SchemaRef{Value: &Schema{Type: &Types{"string"}, Format: "byte"}}
-					} else {
-						result = &SchemaRef{Value: &Schema{Type: &Types{"array"}, Items: generateSchema(fieldType.Elem(), false)}}
-					}
-				case reflect.Struct:
-					result = generateSchema(fieldType, false)
-				default:
-					result = &SchemaRef{Value: getDefaultSchema(fieldType)}
-				}
-			}
+// generateFieldSchema generates the schema of a single struct field, respecting its tags.
+// Anonymous structs get named after the parent component and the field (eg. "UserAddress").
+// Returns nil for fields which can't be represented (funcs, channels).
+func (g *Generator) generateFieldSchema(parentType reflect.Type, field reflect.StructField, fieldName string, parent *Schema, parentName string) *SchemaRef {
+	nameHint := nestedSchemaName(parentName, field.Name)
+	fieldType := field.Type
+	isNullable := false
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+		isNullable = true
+	}
 
-			fieldSchema := *result.Value
-			fieldResult := &SchemaRef{
-				Ref:   result.Ref,
-				Value: &fieldSchema,
-			}
-			fieldResult.Value.Nullable = isNullable
-			fieldName := field.Name
-			for _, tag := range []string{jsonTag, formTag, queryTag} {
-				if parts := strings.Split(tag, ","); parts[0] != "" && parts[0] != "-" {
-					fieldName = parts[0]
-					break
-				}
-			}
-			fieldResult.Value.Title = fieldName
+	result := g.generateSchema(fieldType, false, nameHint)
+	if result == nil {
+		return nil
+	}
 
-			parseTags(field, fieldResult)
-			if implementsSwaggerEnum(fieldType) {
-				handleEnumValues(fieldResult, getSwaggerEnumValues(fieldType), false, fieldType)
-			}
-			applyValidationTags(field, fieldResult, schema, fieldName)
+	fieldSchema := *result.Value
+	fieldResult := &SchemaRef{
+		Ref:   result.Ref,
+		Value: &fieldSchema,
+	}
+	fieldResult.Value.Nullable = isNullable || result.Value.Nullable
+	fieldResult.Value.Title = fieldName
+	if doc := g.docComments().fieldDoc(parentType, field); doc != "" {
+		fieldResult.Value.Description = doc
+	}
 
-			schema.Properties[fieldName] = fieldResult
+	parseTags(parentType, field, fieldResult)
+	g.applyValidationTags(parentType, field, fieldResult, parent, fieldName)
+	// applied last, since enums and validation tags overwrite the default
+	if _, ok := field.Tag.Lookup("default"); ok {
+		if value, ok := tagValue("default", parentType, field, fieldType, fieldResult.Value); ok {
+			fieldResult.Value.Default = value
 		}
+	}
 
-		setToAcquiredSchemas(ref, &SchemaRef{Value: schema})
-		return &SchemaRef{Ref: refPath, Value: schema}
+	_, hasTitle := field.Tag.Lookup("title")
+	return wrapRefSiblings(fieldResult, result.Value, hasTitle)
+}
+
+// wrapRefSiblings wraps the $ref of a field using allOf when the field sets keywords next to it (nullable, description, example, ...),
+// since keywords next to a $ref get dropped (see splitReadWriteSchemas). Fields which aren't a $ref get returned as they are.
+// fieldResult.Value is the copy of the component the field keywords were applied to, hasTitle reports whether the title was set by a tag.
+func wrapRefSiblings(fieldResult *SchemaRef, component *Schema, hasTitle bool) *SchemaRef {
+	if fieldResult.Ref == "" {
+		return fieldResult
 	}
+	if siblings := refSiblings(fieldResult.Value, component, hasTitle); siblings != nil {
+		siblings.AllOf = SchemaRefs{&SchemaRef{Ref: fieldResult.Ref, Value: component}}
+		return &SchemaRef{Value: siblings}
+	}
+	return fieldResult
+}
 
-	return &SchemaRef{Value: schema}
+// refSiblings returns the keywords the field sets next to the $ref of its component, or nil if it doesn't set any.
+func refSiblings(fieldSchema *Schema, component *Schema, hasTitle bool) *Schema {
+	siblings := &Schema{
+		Nullable:   fieldSchema.Nullable && !component.Nullable,
+		Format:     fieldSchema.Format,
+		Example:    fieldSchema.Example,
+		Default:    fieldSchema.Default,
+		Deprecated: fieldSchema.Deprecated && !component.Deprecated,
+		ReadOnly:   fieldSchema.ReadOnly && !component.ReadOnly,
+		WriteOnly:  fieldSchema.WriteOnly && !component.WriteOnly,
+	}
+	if hasTitle {
+		siblings.Title = fieldSchema.Title
+	}
+	if fieldSchema.Description != component.Description {
+		siblings.Description = fieldSchema.Description
+	}
+	if siblings.Format == component.Format {
+		siblings.Format = ""
+	}
+	if siblings.Title == "" && siblings.Description == "" && siblings.Format == "" && siblings.Example == nil && siblings.Default == nil &&
+		!siblings.Nullable && !siblings.Deprecated && !siblings.ReadOnly && !siblings.WriteOnly {
+		return nil
+	}
+	return siblings
 }
 
 func getDefaultSchema(t reflect.Type) *Schema {
@@ -268,9 +308,9 @@ func getDefaultSchema(t reflect.Type) *Schema {
 
 	switch t.Kind() {
 	case reflect.Bool:
-		schema.Type, schema.Default = &Types{"boolean"}, false
+		schema.Type = &Types{"boolean"}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		schema.Type, schema.Default = &Types{"integer"}, 0
+		schema.Type = &Types{"integer"}
 		switch t.Kind() {
 		case reflect.Int:
 			schema.Min, schema.Max = &minInt, &maxInt
@@ -284,7 +324,7 @@ func getDefaultSchema(t reflect.Type) *Schema {
 			schema.Format, schema.Min, schema.Max = "int64", &minInt64, &maxInt64
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		schema.Type, schema.Default, schema.Min = &Types{"integer"}, 0, &zeroUInt
+		schema.Type, schema.Min = &Types{"integer"}, &zeroUInt
 		switch t.Kind() {
 		case reflect.Uint:
 			schema.Max = &maxUint
@@ -298,24 +338,23 @@ func getDefaultSchema(t reflect.Type) *Schema {
 			schema.Max = &maxUint64
 		}
 	case reflect.Float32, reflect.Float64:
-		schema.Type, schema.Default = &Types{"number"}, 0
+		schema.Type = &Types{"number"}
 		if t.Kind() == reflect.Float32 {
 			schema.Format, schema.Min, schema.Max = "float", &minFloat32, &maxFloat32
 		} else {
 			schema.Format, schema.Min, schema.Max = "double", &minFloat64, &maxFloat64
 		}
 	case reflect.String:
-		schema.Type, schema.Default = &Types{"string"}, ""
+		schema.Type = &Types{"string"}
 	}
 	return schema
 }
 
-func parseTags(field reflect.StructField, result *SchemaRef) {
+func parseTags(parentType reflect.Type, field reflect.StructField, result *SchemaRef) {
 	swaggerType := field.Tag.Get("swaggertype")
 	if swaggerType != "" {
 		result.Ref = ""
 		result.Value.Properties = nil
-		result.Value.Default = nil
 		if strings.HasPrefix(swaggerType, "[]") {
 			result.Value.Type = &Types{"array"}
 			itemType := strings.TrimPrefix(swaggerType, "[]")
@@ -328,15 +367,10 @@ func parseTags(field reflect.StructField, result *SchemaRef) {
 		}
 	}
 
+	// omitempty / omitzero only omit the zero value, they don't make the field nullable
 	jsonTag := field.Tag.Get("json")
-	for _, opt := range strings.Split(jsonTag, ",")[1:] {
-		switch opt {
-		case "string":
-			result.Value.Type = &Types{"string"}
-		case "omitempty", "omitzero":
-			result.Value.Nullable = true
-			result.Value.Description += " " + opt + " "
-		}
+	if slices.Contains(strings.Split(jsonTag, ",")[1:], "string") {
+		result.Value.Type = &Types{"string"}
 	}
 
 	xmlTag := field.Tag.Get("xml")
@@ -353,98 +387,124 @@ func parseTags(field reflect.StructField, result *SchemaRef) {
 			case "attr":
 				result.Value.XML.Attribute = true
 			case "omitempty":
-				result.Value.Nullable = true
+				// see the json omitempty above
 			default:
 				result.Value.Description += " " + opt + " "
 			}
 		}
 	}
-}
 
-func applyValidationTags(field reflect.StructField, result *SchemaRef, parent *Schema, fieldName string) {
-	validate := field.Tag.Get("validate")
-	if validate == "" {
-		return
+	if title, ok := field.Tag.Lookup("title"); ok {
+		result.Value.Title = title
+	}
+	if description, ok := field.Tag.Lookup("description"); ok {
+		result.Value.Description = description
+	}
+	if format, ok := field.Tag.Lookup("format"); ok {
+		result.Value.Format = format
+	}
+	if pattern, ok := field.Tag.Lookup("pattern"); ok {
+		result.Value.Pattern = pattern
+	}
+	if _, ok := field.Tag.Lookup("example"); ok {
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if value, ok := tagValue("example", parentType, field, fieldType, result.Value); ok {
+			result.Value.Example = value
+		}
+	}
+	if field.Tag.Get("deprecated") == "true" {
+		result.Value.Deprecated = true
+	}
+	if field.Tag.Get("readOnly") == "true" {
+		result.Value.ReadOnly = true
 	}
+	if field.Tag.Get("writeOnly") == "true" {
+		result.Value.WriteOnly = true
+	}
+	for _, opt := range strings.Split(field.Tag.Get("swagger"), ",") {
+		switch strings.TrimSpace(opt) {
+		case "readonly":
+			result.Value.ReadOnly = true
+		case "writeonly":
+			result.Value.WriteOnly = true
+		}
+	}
+}
 
-	kind := field.Type.Kind()
-	for _, v := range strings.Split(validate, ",") {
-		parts := strings.SplitN(v, "=", 2)
-		key := parts[0]
+// tagValue parses the example / default tag of the field. Unparsable values are left out of the docs with a warning,
+// since documenting them as strings would make the example (or default) invalid against the schema of the field.
+func tagValue(tag string, parentType reflect.Type, field reflect.StructField, fieldType reflect.Type, schema *Schema) (any, bool) {
+	raw := field.Tag.Get(tag)
+	value, ok := parseTagValue(raw, fieldType, schema)
+	if !ok {
+		log.Println("gofiber-swagger: the "+tag+" tag \""+raw+"\" of", parentType.String()+"."+field.Name, "doesn't match the type of the field, leaving it out of the docs.")
+	}
+	return value, ok
+}
 
-		var value string
-		if len(parts) > 1 {
-			value = parts[1]
+// parseTagValue parses the value of a tag (eg. `example:"42"`) into the go type of the field, so it ends up in the docs as a number instead of "42".
+// When the documented type differs from the go type (eg. `swaggertype:"integer"` or time.Time documented as a string), the documented one wins.
+// Arrays can be either comma separated or json, maps / structs are expected to be json. Unparsable values are returned as strings along with false.
+func parseTagValue(raw string, t reflect.Type, schema *Schema) (any, bool) {
+	if schema != nil {
+		switch {
+		case schema.Type.Is("string"):
+			return raw, true
+		case schema.Type.Is("integer"):
+			t = reflect.TypeFor[int64]()
+		case schema.Type.Is("number"):
+			t = reflect.TypeFor[float64]()
+		case schema.Type.Is("boolean"):
+			t = reflect.TypeFor[bool]()
 		}
+	}
 
-		switch key {
-		case "required":
-			exists := false
-			for _, r := range parent.Required {
-				if r == fieldName {
-					exists = true
-					break
-				}
-			}
-			if !exists {
-				parent.Required = append(parent.Required, fieldName)
-			}
-			result.Value.Nullable = false
-			result.Value.AllowEmptyValue = false
-		case "min":
-			val, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				continue
-			}
-			if kind == reflect.Slice || kind == reflect.Array {
-				result.Value.MinItems = uint64(val)
-			}
-			if kind == reflect.String {
-				result.Value.MinLength = uint64(val)
-			}
-			if kind >= reflect.Int && kind <= reflect.Float64 {
-				result.Value.Min = &val
-				result.Value.Default = val
-			}
-		case "max":
-			val, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				continue
-			}
-			uVal := uint64(val)
-			if kind == reflect.Slice || kind == reflect.Array {
-				result.Value.MaxItems = &uVal
-			}
-			if kind == reflect.String {
-				result.Value.MaxLength = &uVal
-			}
-			if kind >= reflect.Int && kind <= reflect.Float64 {
-				result.Value.Max = &val
-			}
-		case "oneof":
-			options := []any{}
-			for _, opt := range strings.Split(value, " ") {
-				options = append(options, opt)
-			}
-			handleEnumValues(result, options, true, field.Type)
-		case "uniqueItems":
-			result.Value.UniqueItems = true
-		case "minLength":
-			val, err := strconv.ParseUint(value, 10, 64)
-			if err != nil {
-				continue
+	switch t.Kind() {
+	case reflect.String:
+		return raw, true
+	case reflect.Bool:
+		if val, err := strconv.ParseBool(raw); err == nil {
+			return val, true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return val, true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val, err := strconv.ParseUint(raw, 10, 64); err == nil {
+			return val, true
+		}
+	case reflect.Float32, reflect.Float64:
+		if val, err := strconv.ParseFloat(raw, 64); err == nil {
+			return val, true
+		}
+	case reflect.Slice, reflect.Array:
+		if !strings.HasPrefix(strings.TrimSpace(raw), "[") {
+			elemType := t.Elem()
+			for elemType.Kind() == reflect.Pointer {
+				elemType = elemType.Elem()
 			}
-			result.Value.MinLength = val
-		case "maxLength":
-			val, err := strconv.ParseUint(value, 10, 64)
-			if err != nil {
-				continue
+			values := []any{}
+			for _, item := range strings.Split(raw, ",") {
+				value, ok := parseTagValue(strings.TrimSpace(item), elemType, nil)
+				if !ok {
+					return raw, false
+				}
+				values = append(values, value)
 			}
-			result.Value.MaxLength = &val
-		case "omitnil":
-			result.Value.Description += " omitnil "
+			return values, true
+		}
+		fallthrough
+	default:
+		var val any
+		if err := json.Unmarshal([]byte(raw), &val); err == nil {
+			return val, true
 		}
 	}
+	return raw, false
 }
 
 func isNullType(fieldType reflect.Type, nullFieldName string, uniqueFieldName string) bool {
@@ -463,7 +523,7 @@ func isNullTypeWrapper(fieldType reflect.Type, nullFieldName string, uniqueField
 	return isNullType(fieldType.Field(0).Type, nullFieldName, uniqueFieldName)
 }
 
-func handleEnumValues(result *SchemaRef, options []any, overwrite bool, fieldType reflect.Type) {
+func (g *Generator) handleEnumValues(result *SchemaRef, options []any, overwrite bool, fieldType reflect.Type) {
 	if result.Value.OneOf == nil || overwrite {
 		result.Value.OneOf = []*SchemaRef{}
 	}
@@ -471,8 +531,15 @@ func handleEnumValues(result *SchemaRef, options []any, overwrite bool, fieldTyp
 		result.Value.Enum = []any{}
 	}
 	for _, opt := range options {
-		optSchema := generateSchema(fieldType, true)
-		optSchema.Value.Default = opt
+		optSchema := g.generateSchema(fieldType, true, "")
+		if optSchema == nil {
+			optSchema = &SchemaRef{Value: &Schema{}}
+		}
+		// every option is restricted to its own value, otherwise a valid value would match all of them and break "oneOf"
+		optValue := *optSchema.Value
+		optValue.Default = opt
+		optValue.Enum = []any{opt}
+		optSchema = &SchemaRef{Ref: optSchema.Ref, Value: &optValue}
 		result.Value.OneOf = append(result.Value.OneOf, optSchema)
 		result.Value.Enum = append(result.Value.Enum, opt)
 	}
@@ -1,11 +1,21 @@
 package gofiberswagger
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"maps"
 	"mime/multipart"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -172,7 +182,8 @@ func TestSchema_WithTags(t *testing.T) {
 	// OmitEmpty
 	omitemptySchema := schema.Value.Properties["omitempty_field"]
 	assert.NotNil(t, omitemptySchema)
-	assert.True(t, omitemptySchema.Value.Nullable)
+	assert.False(t, omitemptySchema.Value.Nullable)
+	assert.Empty(t, omitemptySchema.Value.Description)
 
 	// MinMax
 	minmaxSchema := schema.Value.Properties["MinMax"]
@@ -251,6 +262,12 @@ type WithFormAndQueryTags struct {
 	Query string `query:"query_field"`
 }
 
+type WithJsonAndFormTags struct {
+	Name     string `json:"name" form:"form_name"`
+	FormOnly string `form:"form_only"`
+	JsonSkip string `json:"-" form:"json_skip"`
+}
+
 func TestSchema_WithFormAndQueryTags(t *testing.T) {
 	t.Parallel()
 
@@ -259,6 +276,10 @@ func TestSchema_WithFormAndQueryTags(t *testing.T) {
 	assert.NotNil(t, schema.Value)
 
 	assert.NotNil(t, schema.Value.Properties["form_field"])
+
+	// structs using json names get documented the way encoding/json serializes them, form / query tags are ignored
+	mixed := CreateSchema[WithJsonAndFormTags]().Value.Properties
+	assert.ElementsMatch(t, []string{"name", "FormOnly"}, slices.Collect(maps.Keys(mixed)))
 }
 
 type SwaggerTagTypes struct {
@@ -297,3 +318,369 @@ func TestSchema_WithSwaggerTags(t *testing.T) {
 	assert.Equal(t, "object", (*objOverrideSchema.Value.Type)[0])
 	assert.Nil(t, objOverrideSchema.Value.Items)
 }
+
+type DocumentationTags struct {
+	Email      string               `json:"email" description:"Email used for notifications" format:"email" example:"john@example.com" title:"E-mail"`
+	Slug       string               `json:"slug" pattern:"^[a-z]+$"`
+	Age        int                  `json:"age" example:"42" default:"18" validate:"min=1"`
+	Ratio      *float64             `json:"ratio" example:"0.5"`
+	Active     bool                 `json:"active" example:"true" default:"true"`
+	Tags       []string             `json:"tags" example:"a, b"`
+	Ids        []int                `json:"ids" example:"[1,2]"`
+	Labels     map[string]string    `json:"labels" example:"{\"env\":\"prod\"}"`
+	CreatedAt  time.Time            `json:"created_at" example:"2024-01-01T00:00:00Z" readOnly:"true"`
+	Password   string               `json:"password" writeOnly:"true"`
+	OldField   string               `json:"old_field" deprecated:"true"`
+	Count      string               `json:"count" swaggertype:"integer" example:"7"`
+	Unparsable int                  `json:"unparsable" example:"not a number"`
+	BadDefault []int                `json:"bad_default" default:"1,two"`
+	Level      TestEnum             `json:"level" default:"B"`
+	Address    DocumentationAddress `json:"address" description:"Shipping address" title:"Address" example:"{\"city\":\"Prague\"}" deprecated:"true"`
+	Billing    DocumentationAddress `json:"billing"`
+}
+
+type DocumentationAddress struct {
+	City string `json:"city"`
+}
+
+func TestSchema_WithDocumentationTags(t *testing.T) {
+	t.Parallel()
+
+	schema := CreateSchema[DocumentationTags]()
+	props := schema.Value.Properties
+
+	email := props["email"].Value
+	assert.Equal(t, "Email used for notifications", email.Description)
+	assert.Equal(t, "email", email.Format)
+	assert.Equal(t, "john@example.com", email.Example)
+	assert.Equal(t, "E-mail", email.Title)
+
+	assert.Equal(t, "^[a-z]+$", props["slug"].Value.Pattern)
+
+	age := props["age"].Value
+	assert.Equal(t, int64(42), age.Example)
+	assert.Equal(t, int64(18), age.Default)
+	assert.Equal(t, float64(1), *age.Min)
+
+	assert.Equal(t, 0.5, props["ratio"].Value.Example)
+	assert.Equal(t, true, props["active"].Value.Example)
+	assert.Equal(t, true, props["active"].Value.Default)
+	assert.Equal(t, []any{"a", "b"}, props["tags"].Value.Example)
+	assert.Equal(t, []any{float64(1), float64(2)}, props["ids"].Value.Example)
+	assert.Equal(t, map[string]any{"env": "prod"}, props["labels"].Value.Example)
+
+	createdAt := props["created_at"].Value
+	assert.Equal(t, "2024-01-01T00:00:00Z", createdAt.Example)
+	assert.True(t, createdAt.ReadOnly)
+	assert.True(t, props["password"].Value.WriteOnly)
+	assert.True(t, props["old_field"].Value.Deprecated)
+	assert.Equal(t, int64(7), props["count"].Value.Example)
+	// documenting them as strings would make them invalid against the schema
+	assert.Nil(t, props["unparsable"].Value.Example)
+	assert.Nil(t, props["bad_default"].Value.Default)
+	// only the default tag sets a default, not the zero value of the type
+	assert.Nil(t, props["password"].Value.Default)
+	assert.Nil(t, props["unparsable"].Value.Default)
+	assert.Nil(t, props["ratio"].Value.Default)
+	assert.Equal(t, "B", props["level"].Value.Default)
+
+	// keywords next to a $ref would be dropped, so the component gets wrapped using allOf
+	address := props["address"]
+	assert.Empty(t, address.Ref)
+	assert.Equal(t, "#/components/schemas/"+PackageQualifiedSchemaNames(reflect.TypeFor[DocumentationAddress]()), address.Value.AllOf[0].Ref)
+	assert.Equal(t, "Shipping address", address.Value.Description)
+	assert.Equal(t, "Address", address.Value.Title)
+	assert.Equal(t, map[string]any{"city": "Prague"}, address.Value.Example)
+	assert.True(t, address.Value.Deprecated)
+	marshaled, err := json.Marshal(address)
+	assert.NoError(t, err)
+	assert.Contains(t, string(marshaled), `"description":"Shipping address"`)
+	assert.NotEmpty(t, props["billing"].Ref)
+}
+
+type CustomSchemaMoney struct {
+	Amount   int64
+	Currency string
+}
+
+func (CustomSchemaMoney) SwaggerSchema() *Schema {
+	return &Schema{Type: &Types{"string"}, Pattern: `^\d+\.\d{2} [A-Z]{3}$`, Example: "10.00 EUR"}
+}
+
+type CustomSchemaDecimal struct {
+	value string
+}
+
+func (*CustomSchemaDecimal) SwaggerSchema() *Schema {
+	return &Schema{Type: &Types{"string"}, Format: "decimal"}
+}
+
+type CustomSchemas struct {
+	Price     CustomSchemaMoney    `json:"price" description:"Price of the item"`
+	Discount  *CustomSchemaDecimal `json:"discount"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+func TestSchema_WithCustomSchemas(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{})
+	generator.RegisterTypeSchema(reflect.TypeFor[time.Time](), &Schema{Type: &Types{"integer"}, Format: "unix-time"})
+
+	schema := generator.CreateSchema(reflect.TypeFor[CustomSchemas]())
+	props := schema.Value.Properties
+
+	price := props["price"].Value
+	assert.Equal(t, &Types{"string"}, price.Type)
+	assert.Equal(t, "10.00 EUR", price.Example)
+	assert.Equal(t, "Price of the item", price.Description)
+	assert.Empty(t, price.Properties)
+
+	discount := props["discount"].Value
+	assert.Equal(t, "decimal", discount.Format)
+	assert.True(t, discount.Nullable)
+
+	// registered schemas take precedence over the built-in time.Time schema
+	createdAt := props["created_at"].Value
+	assert.Equal(t, &Types{"integer"}, createdAt.Type)
+	assert.Equal(t, "unix-time", createdAt.Format)
+
+	// the tags of a field don't leak into the provided schema
+	assert.Empty(t, generator.CreateSchema(reflect.TypeFor[CustomSchemaMoney]()).Value.Description)
+	assert.Equal(t, "date-time", CreateSchema[time.Time]().Value.Format)
+}
+
+type MarshalerTextId int
+
+func (id MarshalerTextId) MarshalText() ([]byte, error) {
+	return []byte("id-" + strconv.Itoa(int(id))), nil
+}
+
+type MarshalerJson struct {
+	Internal string
+}
+
+func (*MarshalerJson) MarshalJSON() ([]byte, error) {
+	return []byte(`[]`), nil
+}
+
+type MarshalerJsonWithSchema struct {
+	Internal string
+}
+
+func (MarshalerJsonWithSchema) MarshalJSON() ([]byte, error) {
+	return []byte(`[]`), nil
+}
+
+func (MarshalerJsonWithSchema) SwaggerSchema() *Schema {
+	return &Schema{Type: &Types{"array"}, Items: &SchemaRef{Value: &Schema{Type: &Types{"string"}}}}
+}
+
+type MarshalerTextEnum int
+
+func (level MarshalerTextEnum) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[level]), nil
+}
+
+func (MarshalerTextEnum) EnumValues() []any {
+	return []any{"low", "high"}
+}
+
+type MarshalerJsonEnum string
+
+func (status MarshalerJsonEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(string(status)))
+}
+
+func (MarshalerJsonEnum) EnumValues() []any {
+	return []any{"ACTIVE", "DISABLED"}
+}
+
+type Marshalers struct {
+	Id         MarshalerTextId         `json:"id"`
+	Level      MarshalerTextEnum       `json:"level"`
+	Status     MarshalerJsonEnum       `json:"status"`
+	Addr       netip.Addr              `json:"addr"`
+	Opaque     MarshalerJson           `json:"opaque"`
+	WithSchema MarshalerJsonWithSchema `json:"with_schema"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+func TestSchema_WithMarshalers(t *testing.T) {
+	t.Parallel()
+
+	schema := NewGenerator(Config{}).CreateSchema(reflect.TypeFor[Marshalers]())
+	props := schema.Value.Properties
+
+	assert.Equal(t, &Types{"string"}, props["id"].Value.Type)
+	assert.Nil(t, props["id"].Value.Min)
+	assert.Equal(t, &Types{"string"}, props["addr"].Value.Type)
+	assert.Empty(t, props["addr"].Value.Properties)
+
+	opaque := props["opaque"]
+	assert.Empty(t, opaque.Ref)
+	assert.Nil(t, opaque.Value.Type)
+	assert.Empty(t, opaque.Value.Properties)
+
+	assert.Equal(t, &Types{"array"}, props["with_schema"].Value.Type)
+	assert.Equal(t, "date-time", props["created_at"].Value.Format)
+
+	// enums keep their values, documented the way they get serialized
+	assert.Equal(t, &Types{"string"}, props["level"].Value.Type)
+	assert.Equal(t, []any{"low", "high"}, props["level"].Value.Enum)
+	assert.Len(t, props["level"].Value.OneOf, 2)
+	assert.Equal(t, []any{"ACTIVE", "DISABLED"}, props["status"].Value.Enum)
+	assert.Equal(t, []any{"DISABLED"}, props["status"].Value.OneOf[1].Value.Enum)
+}
+
+type JsonSemanticsBase struct {
+	Id        int    `json:"id" validate:"required"`
+	Name      string `json:"name"`
+	CreatedBy string
+	Shadowed  string `json:"shadowed"`
+}
+
+type JsonSemanticsMeta struct {
+	Version int `json:"version"`
+}
+
+type JsonSemanticsConflictA struct {
+	Conflict string `json:"conflict"`
+	Tagged   string `json:"Tagged"`
+}
+
+type JsonSemanticsConflictB struct {
+	Conflict string `json:"conflict"`
+	Tagged   string
+}
+
+type jsonSemanticsUnexported struct {
+	Promoted string `json:"promoted"`
+}
+
+type JsonSemantics struct {
+	JsonSemanticsBase
+	*JsonSemanticsConflictA
+	JsonSemanticsConflictB
+	jsonSemanticsUnexported
+	Meta     JsonSemanticsMeta `json:"meta"`
+	Inline   JsonSemanticsMeta `json:",inline"`
+	Shadowed int               `json:"shadowed"`
+	Optional string            `json:"optional,omitempty"`
+	hidden   string
+}
+
+func TestSchema_WithJsonSemantics(t *testing.T) {
+	t.Parallel()
+
+	schema := NewGenerator(Config{}).CreateSchema(reflect.TypeFor[JsonSemantics]())
+	props := schema.Value.Properties
+
+	keys := []string{}
+	for key := range props {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"id", "name", "CreatedBy", "shadowed", "Tagged", "promoted", "meta", "version", "optional"}, keys)
+	assert.Equal(t, []string{"id"}, schema.Value.Required)
+
+	// the tagged field wins over the untagged one of the same depth
+	for _, field := range schemaFields(reflect.TypeFor[JsonSemantics]()) {
+		if field.name == "Tagged" {
+			assert.Equal(t, reflect.TypeFor[JsonSemanticsConflictA](), field.owner)
+		}
+	}
+	// the least nested field wins
+	assert.Equal(t, &Types{"integer"}, props["shadowed"].Value.Type)
+	// tagged embedded structs are properties, not flattened
+	assert.True(t, strings.HasSuffix(props["meta"].Ref, "JsonSemanticsMeta"))
+	// omitempty doesn't make the field nullable
+	assert.False(t, props["optional"].Value.Nullable)
+	assert.Empty(t, props["optional"].Value.Description)
+}
+
+type UnifiedUser struct {
+	Name string `json:"name"`
+}
+
+type UnifiedPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+type UnifiedTree struct {
+	Value    int                     `json:"value"`
+	Children map[string]*UnifiedTree `json:"children"`
+	Parent   *UnifiedParent          `json:"parent"`
+}
+
+type UnifiedParent struct {
+	Tree *UnifiedTree `json:"tree"`
+}
+
+type UnifiedTypes struct {
+	Users    map[string]UnifiedUser `json:"users"`
+	ById     map[int]UnifiedUser    `json:"by_id"`
+	ByUint   map[uint8]string       `json:"by_uint"`
+	ByText   map[TestTextKey]int    `json:"by_text"`
+	Pages    []UnifiedPage[UnifiedUser]
+	Checksum [4]byte `json:"checksum"`
+	Bytes    []byte  `json:"bytes"`
+	Enum     TestEnum
+	Tree     UnifiedTree `json:"tree"`
+}
+
+type TestTextKey int
+
+func (key TestTextKey) MarshalText() ([]byte, error) {
+	return []byte("key-" + strconv.Itoa(int(key))), nil
+}
+
+func TestSchema_UnifiedTypeWalker(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{})
+	schema := generator.CreateSchema(reflect.TypeFor[UnifiedTypes]())
+	props := schema.Value.Properties
+
+	// top-level types get documented the same way as the fields
+	users := generator.CreateSchema(reflect.TypeFor[map[string]UnifiedUser]())
+	assert.Equal(t, &Types{"object"}, users.Value.Type)
+	assert.Equal(t, props["users"].Value.AdditionalProperties.Schema.Ref, users.Value.AdditionalProperties.Schema.Ref)
+	pages := generator.CreateSchema(reflect.TypeFor[[]UnifiedPage[UnifiedUser]]())
+	assert.Equal(t, props["Pages"].Value.Items.Ref, pages.Value.Items.Ref)
+	assert.Len(t, generator.CreateSchema(reflect.TypeFor[TestEnum]()).Value.Enum, 2)
+	assert.Len(t, props["Enum"].Value.Enum, 2)
+	assert.Len(t, props["Enum"].Value.OneOf, 2)
+
+	// integer keys are serialized as strings
+	byId := props["by_id"].Value
+	assert.Nil(t, byId.AdditionalProperties.Schema)
+	assert.Equal(t, users.Value.AdditionalProperties.Schema.Ref, byId.PatternProperties["^-?[0-9]+$"].Ref)
+	assert.Equal(t, "^-?[0-9]+$", byId.PropertyNames.Value.Pattern)
+	assert.NotNil(t, props["by_uint"].Value.PatternProperties["^[0-9]+$"])
+	assert.NotNil(t, props["by_text"].Value.AdditionalProperties.Schema)
+
+	checksum := props["checksum"].Value
+	assert.Equal(t, &Types{"array"}, checksum.Type)
+	assert.Equal(t, uint64(4), checksum.MinItems)
+	assert.Equal(t, uint64(4), *checksum.MaxItems)
+	assert.Equal(t, "byte", props["bytes"].Value.Format)
+
+	// recursive types reference their components
+	tree := props["tree"]
+	children := tree.Value.Properties["children"].Value.AdditionalProperties.Schema
+	assert.Equal(t, tree.Ref, children.Ref)
+	parent := tree.Value.Properties["parent"].Value.AllOf[0]
+	assert.Equal(t, tree.Ref, parent.Value.Properties["tree"].Value.AllOf[0].Ref)
+
+	for _, version := range []OpenAPIVersion{OpenAPIVersion30, OpenAPIVersion31} {
+		app := fiber.New()
+		router := generator.NewRouter(app)
+		router.Post("/types", &RouteInfo{RequestBody: &RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(schema)}, Responses: NewResponses(NewResponseInfo[string]("200", "OK"))}, func(c fiber.Ctx) error { return nil })
+		config := Config{OpenAPIVersion: version}
+		config.Swagger = swaggerConfigDefault(DefaultSwaggerConfig)
+		assert.NoError(t, generator.register(app, config))
+		document, err := openapi3.NewLoader().LoadFromData(generator.getDocumentSnapshot().schemaAsJson)
+		assert.NoError(t, err)
+		assert.NoError(t, document.Validate(context.Background()), version)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"html/template"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
-	"strconv"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -16,55 +18,177 @@ import (
 )
 
 func Register(app *fiber.App, config Config) error {
+	return defaultGenerator.register(app, config)
+}
+
+func (g *Generator) register(app *fiber.App, config Config) error {
+	built, err := g.buildDocument(app, config)
+	if err != nil {
+		return err
+	}
+	g.setDocumentSnapshot(built.schemaAsJson, built.routes)
+	config = built.config
+
+	if config.CreateSwaggerFiles && !fiber.IsChild() {
+		if config.SwaggerFilesPath == "" {
+			return errors.New("gofiber-swagger: CreateSwaggerFiles was set to true, however SwaggerFilesPaths was left empty")
+		}
+		createSwaggerFiles(config.SwaggerFilesPath, built.indexPage, built.initializer, built.schemaAsJson, built.schemaAsYaml, config.JSONFileName, config.YAMLFileName)
+	}
+
+	existing_routes := map[string]bool{}
+	for _, route := range app.GetRoutes() {
+		existing_routes[getAcquiredRoutesInfoId(route.Method, route.Path)] = true
+	}
+	defer func() {
+		for _, route := range app.GetRoutes() {
+			if !existing_routes[getAcquiredRoutesInfoId(route.Method, route.Path)] {
+				g.addDocsRoute(route.Method, route.Path)
+			}
+		}
+	}()
+
+	// the auth runs per route instead of using the group, since group middlewares of BasePath "/" would protect the whole app
+	swagger_routes := app.Group(config.BasePath)
+	docs_handlers := docsHandlers(config)
+	get := func(path string, handler fiber.Handler) {
+		first_handler, handlers := handlerChain(handler, docs_handlers)
+		swagger_routes.Get(path, first_handler, handlers...)
+	}
+	if !config.DisableUI {
+		index_handler := func(c fiber.Ctx) error {
+			return c.Type("html").Send(built.indexPage)
+		}
+		get("/", index_handler)
+		get("/index.html", index_handler)
+		get("/swagger", index_handler)
+		get("/"+swaggerInitializerFileName, func(c fiber.Ctx) error {
+			return c.Type("js").Send(built.initializer)
+		})
+
+		// only when the UI loads the embedded assets, not when they come from the CDN or a mirror
+		if config.SwaggerUI.AssetsBaseURL == path.Join(config.BasePath, swaggerUIAssetsPath()) {
+			asset_handlers, err := swaggerUIAssetHandlers()
+			if err != nil {
+				return err
+			}
+			for file_name, asset_handler := range asset_handlers {
+				get(swaggerUIAssetsPath()+"/"+file_name, asset_handler)
+			}
+		}
+	}
+	if !config.DisableJSON {
+		get("/"+config.JSONFileName, func(c fiber.Ctx) error {
+			return c.Type("json").Send(built.schemaAsJson)
+		})
+	}
+	if !config.DisableYAML {
+		get("/"+config.YAMLFileName, func(c fiber.Ctx) error {
+			return c.Type("yaml").Send(built.schemaAsYaml)
+		})
+	}
+
+	return nil
+}
+
+// addDocsRoute remembers a route serving the docs (mounted by Register under Config.BasePath),
+// so calling Register (or Export) again doesn't document it as a part of the API.
+func (g *Generator) addDocsRoute(method string, path string) {
+	g.routesMutex.Lock()
+	defer g.routesMutex.Unlock()
+	if g.docsRoutes == nil {
+		g.docsRoutes = make(map[string]bool)
+	}
+	g.docsRoutes[getAcquiredRoutesInfoId(method, path)] = true
+}
+
+func (g *Generator) isDocsRoute(method string, path string) bool {
+	g.routesMutex.Lock()
+	defer g.routesMutex.Unlock()
+	return g.docsRoutes[getAcquiredRoutesInfoId(method, path)]
+}
+
+// isAutoHeadRoute reports whether the route is the HEAD route fiber adds for every GET route (a copy of its handlers),
+// which would duplicate the operation (and its operationId) of the GET route.
+func isAutoHeadRoute(route fiber.Route, routes []fiber.Route) bool {
+	if route.Method != fiber.MethodHead {
+		return false
+	}
+	for _, get := range routes {
+		if get.Method != fiber.MethodGet || get.Path != route.Path || len(get.Handlers) != len(route.Handlers) {
+			continue
+		}
+		same := true
+		for i := range get.Handlers {
+			same = same && reflect.ValueOf(get.Handlers[i]).Pointer() == reflect.ValueOf(route.Handlers[i]).Pointer()
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// builtDocument is everything Register serves, built from the routes of the app, and the config (with its defaults) used to build it.
+type builtDocument struct {
+	config       Config
+	indexPage    []byte
+	initializer  []byte
+	schemaAsJson []byte
+	schemaAsYaml []byte
+	routes       []documentedRoute
+}
+
+// buildDocument builds the document from the routes of the app, without mounting anything.
+// The registered operations are copied before getting completed, so the document can be built multiple times (Register, Export, ...).
+func (g *Generator) buildDocument(app *fiber.App, config Config) (*builtDocument, error) {
+	if err := g.applySchemaConfig(config); err != nil {
+		return nil, err
+	}
+	if err := g.schemaNamingError(); err != nil {
+		return nil, err
+	}
+
 	config.Swagger = swaggerConfigDefault(config.Swagger)
+	if config.OpenAPIVersion != "" {
+		config.Swagger.OpenAPI = string(config.OpenAPIVersion)
+	}
+	config = swaggerRoutesConfigDefault(config)
 	config.SwaggerUI = swaggerUIConfigDefault(config.SwaggerUI)
 
-	schemasMutex.RLock()
-	for k, v := range acquiredSchemas {
+	g.schemasMutex.RLock()
+	for k, v := range g.acquiredSchemas {
 		if config.Swagger.Components.Schemas[k] == nil {
 			config.Swagger.Components.Schemas[k] = v
 		}
 	}
-	schemasMutex.RUnlock()
+	g.schemasMutex.RUnlock()
+
+	doc_comments := config.DocComments
+	if doc_comments == nil {
+		doc_comments = g.docComments()
+	}
 
+	documented_routes := []documentedRoute{}
+	operation_ids := map[string]operationIdOwner{}
+	operation_id_errors := []error{}
 	routes := app.GetRoutes(config.FilterOutAppUse)
 	for _, route := range routes {
-		operation := getAcquiredRoutesInfo(route.Method, route.Path)
+		if g.isDocsRoute(route.Method, route.Path) || (isAutoHeadRoute(route, routes) && g.getAcquiredRoutesInfo(route.Method, route.Path) == nil) {
+			continue
+		}
+		operation := g.getAcquiredRoutesInfo(route.Method, route.Path)
 		if operation == nil {
 			operation = &RouteInfo{}
 		}
+		operation = cloneOperation(operation)
 
-		corrected_path := route.Path
-		for _, param_name := range route.Params {
-			parameter_exists := false
-			if operation.Parameters != nil {
-				for _, p := range operation.Parameters {
-					if p.Value != nil && p.Value.In == openapi3.ParameterInPath && p.Value.Name == param_name {
-						parameter_exists = true
-						break
-					}
-				}
-			}
-
-			if !parameter_exists {
-				parameter := NewPathParameter(param_name)
-				parameter.Value = parameter.Value.WithSchema(NewStringSchema())
+		path_segments := parseRoutePath(route.Path)
+		for _, param := range routePathParams(path_segments) {
+			if !hasPathParameter(operation.Parameters, param.name) {
+				parameter := NewPathParameterExtended(param.name, param.schema())
 				operation.AddParameter(parameter.Value)
 			}
-
-			corrected_path = strings.Replace(corrected_path, ":"+param_name, "{"+param_name+"}", 1)
-			if param_name[0] == '*' || param_name[0] == '+' {
-				char_to_replace := "*"
-				if param_name[0] == '+' {
-					char_to_replace = "+"
-				}
-
-				nth, err := strconv.ParseUint(strings.ReplaceAll(param_name, char_to_replace, ""), 10, 64)
-				if err != nil {
-					return errors.Join(errors.New("unable to parse out the nth position of the param_name \""+param_name+"\""), err)
-				}
-				corrected_path = replaceNthOccurrence(corrected_path, char_to_replace, "{"+param_name+"}", int(nth))
-			}
 		}
 		if config.AppendMethodToTags {
 			operation.Tags = append(operation.Tags, route.Method)
@@ -82,76 +206,154 @@ func Register(app *fiber.App, config Config) error {
 		if operation.Responses == nil {
 			operation.Responses = &Responses{}
 		}
+		if operation.OperationID == "" && config.OperationIdStrategy != nil {
+			operation.OperationID = config.OperationIdStrategy(route.Method, route.Path, operation, routeHandler(route))
+		}
+		if operation.Summary == "" && operation.Description == "" {
+			operation.Summary, operation.Description = splitDocComment(doc_comments.handlerDoc(routeHandler(route)))
+		}
+		for _, name := range missingOperationSchemas(config.Swagger.Components.Schemas, operation) {
+			log.Println("gofiber-swagger: "+route.Method+" "+route.Path+" references the component \""+name+"\" which wasn't generated by its Generator,",
+				"create the schema using generator.CreateSchema (or the route helpers) instead of the top-level CreateSchema")
+		}
 
-		path_item := config.Swagger.Paths.Find(corrected_path)
-		if path_item == nil {
-			path_item = &openapi3.PathItem{}
-		}
-		switch route.Method {
-		case "POST":
-			path_item.Post = operation
-		case "CONNECT":
-			path_item.Connect = operation
-		case "DELETE":
-			path_item.Delete = operation
-		case "GET":
-			path_item.Get = operation
-		case "HEAD":
-			path_item.Head = operation
-		case "OPTIONS":
-			path_item.Options = operation
-		case "PATCH":
-			path_item.Patch = operation
-		case "PUT":
-			path_item.Put = operation
-		case "TRACE":
-			path_item.Trace = operation
-		default:
-			log.Println("gofiber-swagger: unable to translate operation \"", route.Method, "\", skipping...")
-		}
-		config.Swagger.Paths.Set(corrected_path, path_item)
-	}
-
-	index_page, err := generateIndexPage(swaggerUIConfigDefault(config.SwaggerUI))
+		for _, variant := range routePathVariants(path_segments) {
+			path_item := config.Swagger.Paths.Find(variant.path)
+			if path_item == nil {
+				path_item = &openapi3.PathItem{}
+			}
+			variant_operation := operationForPathVariant(operation, variant)
+			setPathItemOperation(path_item, route.Method, variant_operation)
+			config.Swagger.Paths.Set(variant.path, path_item)
+			documented_routes = append(documented_routes, newDocumentedRoute(route.Method, variant.path, app.Config()))
+
+			if variant_operation.OperationID != "" {
+				owner := operationIdOwner{method: route.Method, path: variant.path}
+				if first, taken := operation_ids[variant_operation.OperationID]; taken {
+					operation_id_errors = append(operation_id_errors, duplicateOperationIdError(variant_operation.OperationID, first, owner))
+				} else {
+					operation_ids[variant_operation.OperationID] = owner
+				}
+			}
+		}
+	}
+	if len(operation_id_errors) > 0 {
+		return nil, errors.Join(operation_id_errors...)
+	}
+
+	ui_config := swaggerUIConfigDefault(config.SwaggerUI)
+	index_page, err := generateIndexPage(ui_config, path.Join(config.BasePath, swaggerInitializerFileName))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	schema_as_json, schema_as_yaml, err := generateOpenApiSchema(config.Swagger)
+	initializer, err := generateSwaggerInitializer(ui_config)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	document := config.Swagger
+	if config.SplitReadWriteSchemas {
+		split, err := splitReadWriteSchemas(document)
+		if err != nil {
+			return nil, err
+		}
+		document = *split
+	}
+	if config.GenerateExamples {
+		generated, err := generateExamples(document)
+		if err != nil {
+			return nil, err
+		}
+		document = *generated
+	}
+	schema_as_json, schema_as_yaml, err := generateOpenApiSchema(document)
+	if err != nil {
+		return nil, err
 	}
 
-	if config.CreateSwaggerFiles && !fiber.IsChild() {
-		if config.SwaggerFilesPath == "" {
-			return errors.New("gofiber-swagger: CreateSwaggerFiles was set to true, however SwaggerFilesPaths was left empty")
+	return &builtDocument{config: config, indexPage: index_page, initializer: initializer, schemaAsJson: schema_as_json, schemaAsYaml: schema_as_yaml, routes: documented_routes}, nil
+}
+
+// cloneOperation returns a copy of the operation whose slices (tags, parameters, security) can be appended to without touching the original.
+func cloneOperation(operation *RouteInfo) *RouteInfo {
+	cloned := *operation
+	cloned.Tags = slices.Clone(operation.Tags)
+	cloned.Parameters = slices.Clone(operation.Parameters)
+	if operation.Security != nil {
+		security := slices.Clone(*operation.Security)
+		cloned.Security = &security
+	}
+	return &cloned
+}
+
+func hasPathParameter(parameters Parameters, name string) bool {
+	for _, p := range parameters {
+		if p.Value != nil && p.Value.In == openapi3.ParameterInPath && p.Value.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// operationForPathVariant returns a shallow copy of the operation without the path parameters missing from the variant,
+// or the operation itself if nothing is missing. The operationId of the copy gets suffixed, eg. "getFileWithoutName".
+func operationForPathVariant(operation *RouteInfo, variant routePathVariant) *RouteInfo {
+	parameters := Parameters{}
+	missing := strings.Builder{}
+	for _, p := range operation.Parameters {
+		if p.Value != nil && p.Value.In == openapi3.ParameterInPath && !variant.params[p.Value.Name] {
+			missing.WriteString(pascalCaseWords(p.Value.Name))
+			continue
 		}
-		createSwaggerFiles(config.SwaggerFilesPath, index_page, schema_as_json, schema_as_yaml)
+		parameters = append(parameters, p)
+	}
+	if len(parameters) == len(operation.Parameters) {
+		return operation
 	}
 
-	swagger_routes := app.Group("/swagger")
-	index_handler := func(c fiber.Ctx) error {
-		return c.Type("html").Send(index_page)
+	variant_operation := *operation
+	variant_operation.Parameters = parameters
+	if variant_operation.OperationID != "" {
+		variant_operation.OperationID += "Without" + missing.String()
 	}
-	swagger_routes.Get("/", index_handler)
-	swagger_routes.Get("/index.html", index_handler)
-	swagger_routes.Get("/swagger", index_handler)
-	swagger_routes.Get("/swagger.json", func(c fiber.Ctx) error {
-		return c.Type("json").Send(schema_as_json)
-	})
-	swagger_routes.Get("/swagger.yaml", func(c fiber.Ctx) error {
-		return c.Type("yaml").Send(schema_as_yaml)
-	})
+	return &variant_operation
+}
 
-	return nil
+func setPathItemOperation(path_item *PathItem, method string, operation *RouteInfo) {
+	switch method {
+	case "POST":
+		path_item.Post = operation
+	case "CONNECT":
+		path_item.Connect = operation
+	case "DELETE":
+		path_item.Delete = operation
+	case "GET":
+		path_item.Get = operation
+	case "HEAD":
+		path_item.Head = operation
+	case "OPTIONS":
+		path_item.Options = operation
+	case "PATCH":
+		path_item.Patch = operation
+	case "PUT":
+		path_item.Put = operation
+	case "TRACE":
+		path_item.Trace = operation
+	default:
+		log.Println("gofiber-swagger: unable to translate operation \"", method, "\", skipping...")
+	}
 }
 
-func generateIndexPage(ui_config SwaggerUIConfig) (index_page []byte, err error) {
+// generateIndexPage renders the index page, which loads the UI config from the initializer (see generateSwaggerInitializer).
+func generateIndexPage(ui_config SwaggerUIConfig, initializer_url string) (index_page []byte, err error) {
 	index_tpl, err := template.New("swagger_index.html").Parse(indexPageTmpl)
 	if err != nil {
 		return nil, errors.Join(errors.New("gofiber-swagger: error while parsing the swagger index template -> "), err)
 	}
 	index_tpl_buf := bytes.NewBufferString("")
-	err = index_tpl.Execute(index_tpl_buf, ui_config)
+	err = index_tpl.Execute(index_tpl_buf, struct {
+		SwaggerUIConfig
+		InitializerURL string
+	}{ui_config, initializer_url})
 	if err != nil {
 		return nil, errors.Join(errors.New("gofiber-swagger: error while executing the swagger index template -> "), err)
 	}
@@ -159,6 +361,12 @@ func generateIndexPage(ui_config SwaggerUIConfig) (index_page []byte, err error)
 }
 
 func generateOpenApiSchema(schema openapi3.T) (as_json, as_yaml []byte, err error) {
+	document, err := convertDocumentVersion(schema)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("gofiber-swagger: error while converting the schema to its OpenAPI version -> "), err)
+	}
+	schema = *document
+
 	schema_as_yaml_raw, err := schema.MarshalYAML()
 	if err != nil {
 		return nil, nil, errors.Join(errors.New("gofiber-swagger: error while creating the yaml schema -> "), err)
@@ -176,7 +384,7 @@ func generateOpenApiSchema(schema openapi3.T) (as_json, as_yaml []byte, err erro
 	return schema_as_json, schema_as_yaml, nil
 }
 
-func createSwaggerFiles(target_folder_path string, index_page []byte, schema_as_json []byte, schema_as_yaml []byte) error {
+func createSwaggerFiles(target_folder_path string, index_page []byte, initializer []byte, schema_as_json []byte, schema_as_yaml []byte, json_file_name string, yaml_file_name string) error {
 	var creation_perms os.FileMode = 0o766
 
 	if err := os.MkdirAll(target_folder_path, creation_perms); err != nil {
@@ -187,11 +395,15 @@ func createSwaggerFiles(target_folder_path string, index_page []byte, schema_as_
 		return errors.Join(errors.New("unable to create index.html for swagger files"), err)
 	}
 
-	if err := os.WriteFile(filepath.Join(target_folder_path, "swagger.json"), schema_as_json, creation_perms); err != nil {
+	if err := os.WriteFile(filepath.Join(target_folder_path, swaggerInitializerFileName), initializer, creation_perms); err != nil {
+		return errors.Join(errors.New("unable to create "+swaggerInitializerFileName+" for swagger files"), err)
+	}
+
+	if err := os.WriteFile(filepath.Join(target_folder_path, json_file_name), schema_as_json, creation_perms); err != nil {
 		return errors.Join(errors.New("unable to create swagger.json for swagger files"), err)
 	}
 
-	if err := os.WriteFile(filepath.Join(target_folder_path, "swagger.yaml"), schema_as_yaml, creation_perms); err != nil {
+	if err := os.WriteFile(filepath.Join(target_folder_path, yaml_file_name), schema_as_yaml, creation_perms); err != nil {
 		return errors.Join(errors.New("unable to create swagger.yaml for swagger files"), err)
 	}
 
@@ -2,6 +2,7 @@ package gofiberswagger
 
 import (
 	"fmt"
+	"io"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
@@ -17,21 +18,42 @@ func TestGenerateIndexPage(t *testing.T) {
 
 	// case 1: simple execution
 	cfg := swaggerUIConfigDefault(SwaggerUIConfig{})
-	index, err := generateIndexPage(cfg)
+	index, err := generateIndexPage(cfg, "/swagger/swagger-initializer.js")
 	assert.NoError(t, err)
 	assert.NotNil(t, index)
-	assert.Contains(t, string(index), "SwaggerUIBundle")
+	assert.Contains(t, string(index), `<script src="/swagger/swagger-initializer.js"`)
+	// no inline scripts, so the UI works under a strict CSP
+	assert.NotContains(t, string(index), "<script>")
 
 	// case 2: verify that values are being changed
 	cfg = swaggerUIConfigDefault(SwaggerUIConfig{
 		Title:       "Test",
 		DeepLinking: true,
 	})
-	index, err = generateIndexPage(cfg)
+	index, err = generateIndexPage(cfg, "/swagger/swagger-initializer.js")
 	assert.NoError(t, err)
 	assert.NotNil(t, index)
 	assert.Contains(t, string(index), "<title>Test</title>")
-	assert.Contains(t, string(index), "\"deepLinking\":true")
+}
+
+func TestGenerateSwaggerInitializer(t *testing.T) {
+	t.Parallel()
+
+	cfg := swaggerUIConfigDefault(SwaggerUIConfig{
+		DeepLinking:  true,
+		TagsSorter:   "'alpha'",
+		CustomScript: "console.log('custom');",
+		OAuth:        &OAuthConfig{ClientId: "client"},
+	})
+	initializer, err := generateSwaggerInitializer(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(initializer), "SwaggerUIBundle(config)")
+	assert.Contains(t, string(initializer), `"deepLinking":true`)
+	assert.Contains(t, string(initializer), "SwaggerUIBundle.plugins.DownloadUrl,")
+	assert.Contains(t, string(initializer), "config.tagsSorter = 'alpha';")
+	assert.Contains(t, string(initializer), `config.syntaxHighlight = {"activate":true,"theme":"agate"};`)
+	assert.Contains(t, string(initializer), `ui.initOAuth({"clientId":"client"});`)
+	assert.Contains(t, string(initializer), "console.log('custom');")
 }
 
 func TestGenerateOpenApiSchema(t *testing.T) {
@@ -84,13 +106,15 @@ func TestCreateSwaggerFiles(t *testing.T) {
 
 	temp_dir := t.TempDir()
 	index_page := []byte("index")
+	initializer := []byte("initializer")
 	schema_as_json := []byte("json")
 	schema_as_yaml := []byte("yaml")
 
-	err := createSwaggerFiles(temp_dir, index_page, schema_as_json, schema_as_yaml)
+	err := createSwaggerFiles(temp_dir, index_page, initializer, schema_as_json, schema_as_yaml, "swagger.json", "swagger.yaml")
 	assert.NoError(t, err, "Error while creating the swagger files")
 
 	assert.FileExists(t, filepath.Join(temp_dir, "index.html"))
+	assert.FileExists(t, filepath.Join(temp_dir, "swagger-initializer.js"))
 	assert.FileExists(t, filepath.Join(temp_dir, "swagger.json"))
 	assert.FileExists(t, filepath.Join(temp_dir, "swagger.yaml"))
 
@@ -249,4 +273,67 @@ func TestRegister(t *testing.T) {
 		err := Register(app, Config{})
 		assert.NoError(t, err, "Error while registering swagger")
 	})
+	t.Run("should mount the routes at the configured base path", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		err := NewGenerator(Config{}).register(app, Config{BasePath: "/docs/", JSONFileName: "openapi.json", YAMLFileName: "openapi.yaml"})
+		assert.NoError(t, err, "Error while registering swagger")
+
+		for path, status := range map[string]int{"/docs": 200, "/docs/swagger-initializer.js": 200, "/docs/openapi.json": 200, "/docs/openapi.yaml": 200, "/swagger": 404, "/docs/swagger.json": 404} {
+			resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, status, resp.StatusCode, path)
+		}
+
+		// the UI follows the base path
+		resp, err := app.Test(httptest.NewRequest("GET", "/docs/swagger-initializer.js", nil))
+		assert.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "/docs/openapi.yaml")
+	})
+
+	t.Run("should only serve the enabled routes", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		err := NewGenerator(Config{}).register(app, Config{DisableUI: true, DisableYAML: true})
+		assert.NoError(t, err, "Error while registering swagger")
+
+		for path, status := range map[string]int{"/swagger": 404, "/swagger/index.html": 404, "/swagger/swagger-initializer.js": 404, "/swagger/swagger.json": 200, "/swagger/swagger.yaml": 404} {
+			resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, status, resp.StatusCode, path)
+		}
+
+		config := swaggerRoutesConfigDefault(Config{DisableYAML: true, SwaggerUI: DefaultUIConfig})
+		assert.Equal(t, "/swagger/swagger.json", config.SwaggerUI.URL)
+		config = swaggerRoutesConfigDefault(Config{SwaggerUI: SwaggerUIConfig{URL: "https://example.com/spec.json"}})
+		assert.Equal(t, "https://example.com/spec.json", config.SwaggerUI.URL)
+	})
+}
+
+func TestRegister_Twice(t *testing.T) {
+	t.Parallel()
+
+	for _, base_path := range []string{"/swagger", "/"} {
+		app := fiber.New()
+		generator := NewGenerator(Config{})
+		generator.NewRouter(app).Get("/users", &RouteInfo{Responses: NewResponses(NewResponseInfo[string]("200", "OK"))}, OperationIdTestHandler)
+		app.Get("/undocumented", func(c fiber.Ctx) error { return nil })
+
+		config := Config{BasePath: base_path, OperationIdStrategy: OperationIdFromHandlerName}
+		assert.NoError(t, generator.register(app, config))
+		// serving a request adds the HEAD routes of every GET route
+		_, err := app.Test(httptest.NewRequest("GET", "/users", nil))
+		assert.NoError(t, err)
+
+		// the HEAD routes and the routes serving the docs don't get documented
+		config.Swagger = swaggerConfigDefault(config.Swagger)
+		assert.NoError(t, generator.register(app, config), base_path)
+		assert.ElementsMatch(t, []string{"/undocumented", "/users"}, config.Swagger.Paths.InMatchingOrder(), base_path)
+		assert.Nil(t, config.Swagger.Paths.Find("/users").Head)
+		assert.Equal(t, "OperationIdTestHandler", config.Swagger.Paths.Find("/users").Get.OperationID)
+	}
 }
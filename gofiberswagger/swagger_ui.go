@@ -0,0 +1,186 @@
+package gofiberswagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+/// ------------------------------------------------------------ ///
+/// Swagger UI assets (swagger-ui-dist) embedded into the binary ///
+/// ------------------------------------------------------------ ///
+
+// Version of swagger-ui-dist embedded into the binary, also loaded from the CDN by default (see SwaggerUIConfig.UseCDN).
+// Update it together with the assets using `make update-swagger-ui`.
+const SwaggerUIVersion = "5.20.5"
+
+// The scripts and the stylesheet are stored gzipped, the favicons as they are.
+//
+//go:embed swagger-ui/*.gz swagger-ui/*.png
+var swaggerUIAssets embed.FS
+
+const swaggerUICDN = "https://unpkg.com/swagger-ui-dist@"
+
+// The assets path contains the version, so the browser can cache them for good and still gets the new ones after an update.
+const swaggerUIAssetsCacheControl = "public, max-age=31536000, immutable"
+
+// swaggerUIAssetsPath is the path the embedded assets get served at, relative to the swagger routes.
+func swaggerUIAssetsPath() string {
+	return "/swagger-ui/" + SwaggerUIVersion
+}
+
+// swaggerUIAssetsBaseURL returns where the UI loads its assets from: the AssetsBaseURL set manually,
+// the CDN when UseCDN is set, or the embedded assets served under the base path otherwise.
+func swaggerUIAssetsBaseURL(ui_config SwaggerUIConfig, base_path string) string {
+	switch {
+	case ui_config.AssetsBaseURL != "":
+		return strings.TrimSuffix(ui_config.AssetsBaseURL, "/")
+	case ui_config.UseCDN:
+		version := ui_config.CDNVersion
+		if version == "" {
+			version = SwaggerUIVersion
+		}
+		return swaggerUICDN + version
+	default:
+		return path.Join(base_path, swaggerUIAssetsPath())
+	}
+}
+
+// swaggerUIAssetHandlers returns a handler for every embedded asset, keyed by its file name.
+// Gzipped assets are sent as they are to the clients accepting gzip and decompressed (once) for the rest.
+func swaggerUIAssetHandlers() (map[string]fiber.Handler, error) {
+	entries, err := fs.ReadDir(swaggerUIAssets, "swagger-ui")
+	if err != nil {
+		return nil, errors.Join(errors.New("gofiber-swagger: error while reading the embedded swagger ui assets -> "), err)
+	}
+
+	handlers := map[string]fiber.Handler{}
+	for _, entry := range entries {
+		content, err := swaggerUIAssets.ReadFile("swagger-ui/" + entry.Name())
+		if err != nil {
+			return nil, errors.Join(errors.New("gofiber-swagger: error while reading the embedded swagger ui asset \""+entry.Name()+"\" -> "), err)
+		}
+
+		file_name, gzipped := strings.CutSuffix(entry.Name(), ".gz")
+		content_type := strings.TrimPrefix(path.Ext(file_name), ".")
+		if !gzipped {
+			handlers[file_name] = func(c fiber.Ctx) error {
+				c.Set(fiber.HeaderCacheControl, swaggerUIAssetsCacheControl)
+				return c.Type(content_type).Send(content)
+			}
+			continue
+		}
+
+		decompressed := sync.OnceValues(func() ([]byte, error) {
+			reader, err := gzip.NewReader(bytes.NewReader(content))
+			if err != nil {
+				return nil, err
+			}
+			return io.ReadAll(reader)
+		})
+		handlers[file_name] = func(c fiber.Ctx) error {
+			c.Set(fiber.HeaderCacheControl, swaggerUIAssetsCacheControl)
+			c.Set(fiber.HeaderVary, fiber.HeaderAcceptEncoding)
+			c.Type(content_type)
+			// without the header, AcceptsEncodings would accept the first offer
+			if c.Get(fiber.HeaderAcceptEncoding) != "" && c.AcceptsEncodings("gzip") == "gzip" {
+				c.Set(fiber.HeaderContentEncoding, "gzip")
+				return c.Send(content)
+			}
+			uncompressed, err := decompressed()
+			if err != nil {
+				return errors.Join(errors.New("gofiber-swagger: error while decompressing the swagger ui asset \""+file_name+"\" -> "), err)
+			}
+			return c.Send(uncompressed)
+		}
+	}
+	return handlers, nil
+}
+
+/// ----------------------------------------------------------------------------- ///
+/// Swagger UI initializer, served as a script so the UI works under a strict CSP ///
+/// ----------------------------------------------------------------------------- ///
+
+// The index page doesn't contain any inline scripts, it loads the initializer from the swagger routes instead
+// (the same way swagger-ui-dist does), so `script-src 'self'` is enough to open the UI.
+const swaggerInitializerFileName = "swagger-initializer.js"
+
+// The config gets rendered as json, the options which are functions (template.JS) as they are.
+const swaggerInitializerTmpl string = `{{- if .CustomScript}}
+{{.CustomScript}}
+{{end}}
+window.onload = function() {
+  const config = {{json .}};
+  config.dom_id = '#swagger-ui';
+  config.plugins = [
+    {{- range $plugin := .Plugins }}
+    {{$plugin}},
+    {{- end}}
+  ];
+  config.presets = [
+    {{- range $preset := .Presets }}
+    {{$preset}},
+    {{- end}}
+  ];
+  config.filter = {{json .Filter.Value}};
+  config.syntaxHighlight = {{json .SyntaxHighlight.Value}};
+  {{- if .TagsSorter}}
+  config.tagsSorter = {{.TagsSorter}};
+  {{- end}}
+  {{- if .OnComplete}}
+  config.onComplete = {{.OnComplete}};
+  {{- end}}
+  {{- if .RequestInterceptor}}
+  config.requestInterceptor = {{.RequestInterceptor}};
+  {{- end}}
+  {{- if .ResponseInterceptor}}
+  config.responseInterceptor = {{.ResponseInterceptor}};
+  {{- end}}
+  {{- if .ModelPropertyMacro}}
+  config.modelPropertyMacro = {{.ModelPropertyMacro}};
+  {{- end}}
+  {{- if .ParameterMacro}}
+  config.parameterMacro = {{.ParameterMacro}};
+  {{- end}}
+
+  const ui = SwaggerUIBundle(config);
+  {{- if .OAuth}}
+  ui.initOAuth({{json .OAuth}});
+  {{- end}}
+  {{- if .PreauthorizeBasic}}
+  ui.preauthorizeBasic({{.PreauthorizeBasic}});
+  {{- end}}
+  {{- if .PreauthorizeApiKey}}
+  ui.preauthorizeApiKey({{.PreauthorizeApiKey}});
+  {{- end}}
+
+  window.ui = ui;
+};
+`
+
+func generateSwaggerInitializer(ui_config SwaggerUIConfig) ([]byte, error) {
+	initializer_tpl, err := template.New(swaggerInitializerFileName).Funcs(template.FuncMap{
+		"json": func(value any) (string, error) {
+			encoded, err := json.Marshal(value)
+			return string(encoded), err
+		},
+	}).Parse(swaggerInitializerTmpl)
+	if err != nil {
+		return nil, errors.Join(errors.New("gofiber-swagger: error while parsing the swagger initializer template -> "), err)
+	}
+	initializer_buf := bytes.NewBufferString("")
+	if err := initializer_tpl.Execute(initializer_buf, ui_config); err != nil {
+		return nil, errors.Join(errors.New("gofiber-swagger: error while executing the swagger initializer template -> "), err)
+	}
+	return initializer_buf.Bytes(), nil
+}
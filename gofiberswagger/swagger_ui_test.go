@@ -0,0 +1,102 @@
+package gofiberswagger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func getSwaggerUIRoute(t *testing.T, app *fiber.App, target string, accept_encoding string) (*http.Response, string) {
+	request := httptest.NewRequest("GET", target, nil)
+	if accept_encoding != "" {
+		request.Header.Set(fiber.HeaderAcceptEncoding, accept_encoding)
+	}
+	resp, err := app.Test(request)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestSwaggerUIAssets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should serve the embedded assets by default", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{}))
+
+		_, index := getSwaggerUIRoute(t, app, "/swagger", "")
+		assets_base_url := "/swagger/swagger-ui/" + SwaggerUIVersion
+		assert.Contains(t, index, `src="`+assets_base_url+`/swagger-ui-bundle.js"`)
+		assert.Contains(t, index, `href="`+assets_base_url+`/swagger-ui.css"`)
+		assert.NotContains(t, index, "unpkg.com")
+		assert.NotContains(t, index, "googleapis.com")
+
+		resp, compressed := getSwaggerUIRoute(t, app, assets_base_url+"/swagger-ui-bundle.js", "gzip, deflate")
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "gzip", resp.Header.Get(fiber.HeaderContentEncoding))
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "javascript")
+		assert.Equal(t, swaggerUIAssetsCacheControl, resp.Header.Get(fiber.HeaderCacheControl))
+
+		resp, bundle := getSwaggerUIRoute(t, app, assets_base_url+"/swagger-ui-bundle.js", "")
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(fiber.HeaderContentEncoding))
+		assert.Contains(t, bundle, "SwaggerUIBundle")
+		assert.Contains(t, bundle, `PACKAGE_VERSION:"`+SwaggerUIVersion+`"`)
+		assert.Less(t, len(compressed), len(bundle))
+
+		resp, _ = getSwaggerUIRoute(t, app, assets_base_url+"/swagger-ui.css", "identity")
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "text/css")
+		resp, _ = getSwaggerUIRoute(t, app, assets_base_url+"/favicon-32x32.png", "gzip")
+		assert.Equal(t, "image/png", resp.Header.Get(fiber.HeaderContentType))
+		assert.Empty(t, resp.Header.Get(fiber.HeaderContentEncoding))
+	})
+
+	t.Run("should serve the embedded assets under BasePath", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{BasePath: "/"}))
+
+		_, index := getSwaggerUIRoute(t, app, "/", "")
+		assert.Contains(t, index, `src="/swagger-ui/`+SwaggerUIVersion+`/swagger-ui-standalone-preset.js"`)
+		resp, _ := getSwaggerUIRoute(t, app, "/swagger-ui/"+SwaggerUIVersion+"/swagger-ui-standalone-preset.js", "")
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should load the assets from the CDN", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{SwaggerUI: SwaggerUIConfig{UseCDN: true}}))
+
+		_, index := getSwaggerUIRoute(t, app, "/swagger", "")
+		assert.Contains(t, index, `src="https://unpkg.com/swagger-ui-dist@`+SwaggerUIVersion+`/swagger-ui-bundle.js"`)
+		assert.Contains(t, index, "fonts.googleapis.com")
+		resp, _ := getSwaggerUIRoute(t, app, "/swagger/swagger-ui/"+SwaggerUIVersion+"/swagger-ui-bundle.js", "")
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+		cfg := swaggerUIConfigDefault(SwaggerUIConfig{UseCDN: true, CDNVersion: "5.17.14"})
+		assert.Equal(t, "https://unpkg.com/swagger-ui-dist@5.17.14", cfg.AssetsBaseURL)
+	})
+
+	t.Run("should load the assets from a custom base url", func(t *testing.T) {
+		t.Parallel()
+
+		app := fiber.New()
+		assert.NoError(t, NewGenerator(Config{}).register(app, Config{SwaggerUI: SwaggerUIConfig{AssetsBaseURL: "https://mirror.example.com/swagger-ui/"}}))
+
+		_, index := getSwaggerUIRoute(t, app, "/swagger", "")
+		assert.Contains(t, index, `href="https://mirror.example.com/swagger-ui/swagger-ui.css"`)
+		assert.NotContains(t, index, "googleapis.com")
+		for _, route := range app.GetRoutes(true) {
+			assert.NotContains(t, route.Path, "/swagger-ui/")
+		}
+	})
+}
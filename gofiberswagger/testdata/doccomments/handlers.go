@@ -0,0 +1,29 @@
+package doccomments
+
+// User is a registered user.
+type User struct {
+	// Unique identifier of the user.
+	Id   int
+	Name string // Display name, shown in the UI.
+	Meta
+}
+
+type (
+	// Meta holds metadata shared by multiple types.
+	Meta struct {
+		CreatedAt string
+	}
+)
+
+type Server struct{}
+
+// GetUser returns a single user. The user has to exist.
+//
+// Responds with 404 otherwise.
+func GetUser() {}
+
+// ListUsers lists all users.
+func (s *Server) ListUsers() {}
+
+// Health reports whether the server is up.
+func (s Server) Health() {}
@@ -0,0 +1,186 @@
+package gofiberswagger
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+)
+
+/// --------------------------------------------------------------------------------------------- ///
+/// Typed handlers, the request body and the success response get inferred from the handler types ///
+/// --------------------------------------------------------------------------------------------- ///
+
+// Handler with a request body, which gets bound using `c.Bind().Body()` before the handler is called.
+// The returned value is sent as json with the documented success status (see withTypedResponse), unless the handler sets a different one.
+type TypedHandler[Req any, Res any] func(c fiber.Ctx, req *Req) (Res, error)
+
+// Handler without a request body. The returned value is sent the same way as the one of a TypedHandler.
+type TypedHandlerWithoutBody[Res any] func(c fiber.Ctx) (Res, error)
+
+// NoContent can be returned by typed handlers which don't send a body, the route gets documented (and responds) using 204 No Content.
+type NoContent struct{}
+
+var noContentType = reflect.TypeFor[NoContent]()
+
+func Get[Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandlerWithoutBody[Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Get(path, docs, first_handler, handlers...)
+}
+func Head[Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandlerWithoutBody[Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Head(path, docs, first_handler, handlers...)
+}
+func Options[Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandlerWithoutBody[Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Options(path, docs, first_handler, handlers...)
+}
+
+// Delete doesn't bind a request body, since most APIs don't send one, use DeleteWithBody for the ones that do.
+func Delete[Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandlerWithoutBody[Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Delete(path, docs, first_handler, handlers...)
+}
+func Post[Req any, Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandler[Req, Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	docs = withTypedRequestBody[Req](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Post(path, docs, first_handler, handlers...)
+}
+func Put[Req any, Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandler[Req, Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	docs = withTypedRequestBody[Req](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Put(path, docs, first_handler, handlers...)
+}
+func Patch[Req any, Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandler[Req, Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	docs = withTypedRequestBody[Req](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Patch(path, docs, first_handler, handlers...)
+}
+func DeleteWithBody[Req any, Res any](router SwaggerRouter, path string, docs *RouteInfo, handler TypedHandler[Req, Res], middlewares ...any) fiber.Router {
+	docs, status := withTypedResponse[Res](router, docs)
+	docs = withTypedRequestBody[Req](router, docs)
+	first_handler, handlers := handlerChain(handler.toFiberHandler(status), middlewares)
+	return router.Delete(path, docs, first_handler, handlers...)
+}
+
+func (handler TypedHandler[Req, Res]) toFiberHandler(status int) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		request_body := new(Req)
+		if err := c.Bind().Body(request_body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		previous := withTypedStatus(c, status)
+		response, err := handler(c, request_body)
+		if err != nil {
+			restoreStatus(c, status, previous)
+			return err
+		}
+		return sendTypedResponse(c, response)
+	}
+}
+
+func (handler TypedHandlerWithoutBody[Res]) toFiberHandler(status int) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		previous := withTypedStatus(c, status)
+		response, err := handler(c)
+		if err != nil {
+			restoreStatus(c, status, previous)
+			return err
+		}
+		return sendTypedResponse(c, response)
+	}
+}
+
+// withTypedStatus sets the documented success status before the handler runs, so a status the handler sets (even 200) wins.
+// A status set by the middlewares (anything but the default 200) is kept. Returns the status it replaced (see restoreStatus).
+func withTypedStatus(c fiber.Ctx, status int) int {
+	previous := c.Response().StatusCode()
+	if previous == fiber.StatusOK {
+		c.Status(status)
+	}
+	return previous
+}
+
+// restoreStatus restores the status the typed status replaced when the handler fails, unless the handler set its own.
+func restoreStatus(c fiber.Ctx, status int, previous int) {
+	if c.Response().StatusCode() == status {
+		c.Status(previous)
+	}
+}
+
+// sendTypedResponse sends the response as JSON, NoContent and 204 responses without a body.
+func sendTypedResponse[Res any](c fiber.Ctx, response Res) error {
+	if _, ok := any(response).(NoContent); ok || c.Response().StatusCode() == fiber.StatusNoContent {
+		return nil
+	}
+	return c.JSON(response)
+}
+
+// the middlewares run before the handler, same as they would when passed to fiber directly
+func handlerChain(handler fiber.Handler, middlewares []any) (any, []any) {
+	chain := append(append([]any{}, middlewares...), handler)
+	return chain[0], chain[1:]
+}
+
+// Only fills in what wasn't documented manually, so you can still override the request body or the success response.
+func withTypedRequestBody[Req any](router SwaggerRouter, docs *RouteInfo) *RouteInfo {
+	if docs.RequestBody == nil {
+		request_body := openapi3.NewRequestBody().WithRequired(true)
+		request_body.WithSchemaRef(router.getGenerator().CreateSchema(reflect.TypeFor[Req]()), DefaultRequestBodyConsumes)
+		withTypeExamples[Req](request_body.Content)
+		docs.RequestBody = &RequestBodyRef{Value: request_body}
+	}
+	return docs
+}
+
+// withTypedResponse documents the response of the handler under its success status (see typedSuccessStatus), which it returns.
+// A response documented manually keeps its content, the one without any (eg. `"201": {Description: "Created"}`) gets it filled in.
+func withTypedResponse[Res any](router SwaggerRouter, docs *RouteInfo) (*RouteInfo, int) {
+	if docs == nil {
+		docs = &RouteInfo{}
+	}
+	if docs.Responses == nil {
+		docs.Responses = &Responses{}
+	}
+
+	status := typedSuccessStatus(docs, reflect.TypeFor[Res]())
+	code := strconv.Itoa(status)
+	response := docs.Responses.Value(code)
+	if response == nil {
+		response = &ResponseRef{Value: openapi3.NewResponse().WithDescription(http.StatusText(status))}
+		docs.Responses.Set(code, response)
+	}
+	if response.Value != nil && len(response.Value.Content) == 0 && status != fiber.StatusNoContent {
+		response.Value.WithJSONSchemaRef(router.getGenerator().CreateSchema(reflect.TypeFor[Res]()))
+		withTypeExamples[Res](response.Value.Content)
+	}
+	return docs, status
+}
+
+// typedSuccessStatus returns the lowest 2xx status documented manually (eg. 201 or 204),
+// 204 for handlers returning NoContent and 200 otherwise.
+func typedSuccessStatus(docs *RouteInfo, response_type reflect.Type) int {
+	status := 0
+	for code := range docs.Responses.Map() {
+		if documented, err := strconv.Atoi(code); err == nil && documented >= 200 && documented < 300 && (status == 0 || documented < status) {
+			status = documented
+		}
+	}
+	switch {
+	case status != 0:
+		return status
+	case response_type == noContentType:
+		return fiber.StatusNoContent
+	default:
+		return fiber.StatusOK
+	}
+}
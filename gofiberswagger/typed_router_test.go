@@ -0,0 +1,176 @@
+package gofiberswagger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type TypedCreateUserRequest struct {
+	Name string `json:"name"`
+}
+
+type TypedUserResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestTypedHandlers(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+
+	Post(router, "/users", &RouteInfo{Summary: "create"}, func(c fiber.Ctx, req *TypedCreateUserRequest) (TypedUserResponse, error) {
+		c.Status(http.StatusCreated)
+		return TypedUserResponse{Id: 1, Name: req.Name}, nil
+	})
+	Get(router, "/users/:id", nil, func(c fiber.Ctx) (TypedUserResponse, error) {
+		if c.Params("id") != "1" {
+			return TypedUserResponse{}, fiber.ErrNotFound
+		}
+		return TypedUserResponse{Id: 1, Name: "John"}, nil
+	})
+
+	// docs
+	post_docs := generator.getAcquiredRoutesInfo("POST", "/users")
+	assert.NotNil(t, post_docs)
+	assert.Equal(t, "create", post_docs.Summary)
+	assert.NotNil(t, post_docs.RequestBody)
+	assert.True(t, post_docs.RequestBody.Value.Required)
+	assert.Contains(t, post_docs.RequestBody.Value.Content["application/json"].Schema.Ref, "TypedCreateUserRequest")
+	assert.Contains(t, post_docs.Responses.Value("200").Value.Content["application/json"].Schema.Ref, "TypedUserResponse")
+
+	get_docs := generator.getAcquiredRoutesInfo("GET", "/users/:id")
+	assert.NotNil(t, get_docs)
+	assert.Nil(t, get_docs.RequestBody)
+	assert.NotNil(t, get_docs.Responses.Value("200"))
+
+	// handlers
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":"Jane"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.JSONEq(t, `{"id":1,"name":"Jane"}`, string(body))
+
+	req = httptest.NewRequest("POST", "/users", strings.NewReader(`{invalid`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/users/1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/users/2", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestTypedHandlers_KeepManualDocsAndMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+
+	middleware_called := false
+	manual_response := NewResponses(NewResponseInfo[string]("200", "manual"))
+	Put(router, "/users", &RouteInfo{Responses: manual_response}, func(c fiber.Ctx, req *TypedCreateUserRequest) (string, error) {
+		assert.True(t, middleware_called)
+		return req.Name, nil
+	}, func(c fiber.Ctx) error {
+		middleware_called = true
+		return c.Next()
+	})
+
+	docs := generator.getAcquiredRoutesInfo("PUT", "/users")
+	assert.Equal(t, "manual", *docs.Responses.Value("200").Value.Description)
+
+	req := httptest.NewRequest("PUT", "/users", strings.NewReader(`{"name":"Jane"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, middleware_called)
+}
+
+func TestTypedHandlers_SuccessStatus(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	generator := NewGenerator(Config{})
+	router := generator.NewRouter(app)
+
+	created := NewResponsesRaw(map[string]*ResponseRef{"201": {Value: openapi3.NewResponse().WithDescription("Created")}})
+	Post(router, "/users", &RouteInfo{Responses: created}, func(c fiber.Ctx, req *TypedCreateUserRequest) (TypedUserResponse, error) {
+		return TypedUserResponse{Id: 1, Name: req.Name}, nil
+	})
+	Delete(router, "/users/:id", nil, func(c fiber.Ctx) (NoContent, error) {
+		return NoContent{}, nil
+	})
+	DeleteWithBody(router, "/users", nil, func(c fiber.Ctx, req *TypedCreateUserRequest) (TypedUserResponse, error) {
+		return TypedUserResponse{Name: req.Name}, nil
+	})
+	// the status set by the handler wins, even 200
+	Put(router, "/users/:id", &RouteInfo{Responses: created}, func(c fiber.Ctx, req *TypedCreateUserRequest) (TypedUserResponse, error) {
+		c.Status(http.StatusOK)
+		return TypedUserResponse{Name: req.Name}, nil
+	})
+	ok := NewResponsesRaw(map[string]*ResponseRef{"200": {Value: openapi3.NewResponse().WithDescription("OK")}})
+	Get(router, "/ping", &RouteInfo{Responses: ok}, func(c fiber.Ctx) (NoContent, error) {
+		return NoContent{}, nil
+	})
+
+	// docs
+	post_docs := generator.getAcquiredRoutesInfo("POST", "/users")
+	assert.Nil(t, post_docs.Responses.Value("200"))
+	assert.Equal(t, "Created", *post_docs.Responses.Value("201").Value.Description)
+	assert.Contains(t, post_docs.Responses.Value("201").Value.Content["application/json"].Schema.Ref, "TypedUserResponse")
+
+	delete_docs := generator.getAcquiredRoutesInfo("DELETE", "/users/:id")
+	assert.Nil(t, delete_docs.RequestBody)
+	assert.Nil(t, delete_docs.Responses.Value("200"))
+	assert.Equal(t, "No Content", *delete_docs.Responses.Value("204").Value.Description)
+	assert.Empty(t, delete_docs.Responses.Value("204").Value.Content)
+
+	delete_with_body_docs := generator.getAcquiredRoutesInfo("DELETE", "/users")
+	assert.NotNil(t, delete_with_body_docs.RequestBody)
+	assert.NotNil(t, delete_with_body_docs.Responses.Value("200"))
+
+	// handlers
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":"Jane"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("DELETE", "/users/1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Empty(t, body)
+
+	req = httptest.NewRequest("PUT", "/users/1", strings.NewReader(`{"name":"Jane"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// NoContent doesn't send a body, even on a route documented using 200
+	resp, err = app.Test(httptest.NewRequest("GET", "/ping", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Empty(t, body)
+}
@@ -43,11 +43,17 @@ var (
 	maxFloat64 = float64(math.MaxFloat64)
 )
 
-func replaceNthOccurrence(s, old, new string, n int) string {
-	parts := strings.Split(s, old)
-	if n <= 0 || n >= len(parts) {
-		return s
+// applyDatetimeLayout translates a go time layout into the matching string format,
+// layouts without one get mentioned in the description instead.
+func applyDatetimeLayout(schema *Schema, layout string) {
+	switch layout {
+	case "", time.RFC3339, time.RFC3339Nano:
+		schema.Format = "date-time"
+	case time.DateOnly:
+		schema.Format = "date"
+	case time.TimeOnly:
+		schema.Format = "time"
+	default:
+		schema.Description = strings.TrimSpace(schema.Description + " Go time layout: " + layout)
 	}
-	result := strings.Join(parts[:n], old) + new + strings.Join(parts[n:], old)
-	return result
 }
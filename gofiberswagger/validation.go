@@ -0,0 +1,515 @@
+package gofiberswagger
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+/// ------------------------------------------------------------------------------------ ///
+/// Translation of go-playground/validator tags (`validate:"..."`) into json schema keywords ///
+/// ------------------------------------------------------------------------------------ ///
+
+// ValidationTagMapping applies a validator tag to the schema of the field it's used on.
+// param is the part after "=" (empty if there is none) and t is the go type the tag validates
+// (the field type, or the element / key type after `dive` / `keys`).
+type ValidationTagMapping func(schema *Schema, param string, t reflect.Type)
+
+var (
+	validationTagMappingsMutex sync.RWMutex
+	validationTagMappings      = map[string]ValidationTagMapping{}
+)
+
+// RegisterValidationTag registers the schema mapping of a custom validator tag (the one passed to validator's RegisterValidation).
+// Built-in tags can be overridden the same way.
+func RegisterValidationTag(tag string, mapping ValidationTagMapping) {
+	validationTagMappingsMutex.Lock()
+	defer validationTagMappingsMutex.Unlock()
+	validationTagMappings[tag] = mapping
+}
+
+func getValidationTagMapping(tag string) ValidationTagMapping {
+	validationTagMappingsMutex.RLock()
+	defer validationTagMappingsMutex.RUnlock()
+	return validationTagMappings[tag]
+}
+
+func (g *Generator) applyValidationTags(parentType reflect.Type, field reflect.StructField, result *SchemaRef, parent *Schema, fieldName string) {
+	validate := field.Tag.Get("validate")
+	if validate == "" {
+		return
+	}
+
+	// the tags apply to the field itself, until `dive` moves them to the elements (or `keys` to the keys of a map)
+	target, targetType := result.Value, derefType(field.Type)
+	var mapSchema *Schema
+	var mapType reflect.Type
+	for _, v := range strings.Split(validate, ",") {
+		key, value, _ := strings.Cut(v, "=")
+
+		switch key {
+		case "required":
+			if target == result.Value {
+				if !slices.Contains(parent.Required, fieldName) {
+					parent.Required = append(parent.Required, fieldName)
+				}
+				result.Value.AllowEmptyValue = false
+			}
+			target.Nullable = false
+		case "dive":
+			mapSchema, mapType = nil, nil
+			switch targetType.Kind() {
+			case reflect.Slice, reflect.Array:
+				if target.Items == nil {
+					target.Items = &SchemaRef{Value: &Schema{}}
+				}
+				target, targetType = ownedSchema(target.Items), derefType(targetType.Elem())
+			case reflect.Map:
+				mapSchema, mapType = target, targetType
+				target, targetType = mapValueSchema(mapSchema), derefType(mapType.Elem())
+			default:
+				// nothing to dive into, the rest of the tags can't be translated
+				return
+			}
+		case "keys":
+			if mapSchema != nil {
+				if mapSchema.PropertyNames == nil {
+					mapSchema.PropertyNames = &SchemaRef{Value: &Schema{Type: &Types{"string"}}}
+				}
+				target, targetType = ownedSchema(mapSchema.PropertyNames), derefType(mapType.Key())
+			}
+		case "endkeys":
+			if mapSchema != nil {
+				target, targetType = mapValueSchema(mapSchema), derefType(mapType.Elem())
+			}
+		case "oneof":
+			options := []any{}
+			for _, opt := range splitOneOfValues(value) {
+				options = append(options, validationTagValue(opt, targetType))
+			}
+			g.handleEnumValues(&SchemaRef{Value: target}, options, true, targetType)
+		case "omitnil":
+			target.Description += " omitnil "
+		default:
+			if target == result.Value && applyConditionalValidationTag(parentType, parent, result.Value, fieldName, key, value) {
+				continue
+			}
+			if !strings.Contains(v, "|") {
+				applyValidationTag(target, key, value, targetType)
+			} else {
+				// `a|b` passes when any of the alternatives does
+				for _, alternative := range strings.Split(v, "|") {
+					key, value, _ := strings.Cut(alternative, "=")
+					alternativeSchema := &Schema{}
+					if applyValidationTag(alternativeSchema, key, value, targetType) {
+						target.AnyOf = append(target.AnyOf, &SchemaRef{Value: alternativeSchema})
+					}
+				}
+			}
+		}
+	}
+}
+
+// applyValidationTag applies a single tag, returns false if the tag has no json schema equivalent (eg. cross-field tags).
+func applyValidationTag(schema *Schema, tag string, param string, t reflect.Type) bool {
+	if mapping := getValidationTagMapping(tag); mapping != nil {
+		mapping(schema, param, t)
+		return true
+	}
+
+	kind := t.Kind()
+	isNumber := kind >= reflect.Int && kind <= reflect.Float64
+	isString := kind == reflect.String
+	isCollection := kind == reflect.Slice || kind == reflect.Array
+	isMap := kind == reflect.Map
+
+	// length of strings / collections / maps, value of numbers
+	setBound := func(lower bool, val float64, exclusive bool) bool {
+		switch {
+		case isNumber:
+			if exclusive && lower {
+				schema.Min, schema.ExclusiveMin = nil, openapi3.ExclusiveBound{Value: &val}
+			} else if exclusive {
+				schema.Max, schema.ExclusiveMax = nil, openapi3.ExclusiveBound{Value: &val}
+			} else if lower {
+				schema.Min = &val
+			} else {
+				schema.Max = &val
+			}
+			return true
+		case isString, isCollection, isMap:
+			if exclusive && lower {
+				val++
+			} else if exclusive {
+				val--
+			}
+			length := uint64(max(val, 0))
+			switch {
+			case isString && lower:
+				schema.MinLength = length
+			case isString:
+				schema.MaxLength = &length
+			case isCollection && lower:
+				schema.MinItems = length
+			case isCollection:
+				schema.MaxItems = &length
+			case lower:
+				schema.MinProps = length
+			default:
+				schema.MaxProps = &length
+			}
+			return true
+		}
+		return false
+	}
+
+	if pattern, ok := validationTagPatterns[tag]; ok {
+		addPattern(schema, pattern)
+		return true
+	}
+	if format, ok := validationTagFormats[tag]; ok {
+		schema.Format = format
+		return true
+	}
+
+	val, err := strconv.ParseFloat(param, 64)
+	hasNumericParam := err == nil
+	switch tag {
+	case "min":
+		if !hasNumericParam {
+			return false
+		}
+		return setBound(true, val, false)
+	case "max":
+		return hasNumericParam && setBound(false, val, false)
+	case "len":
+		return hasNumericParam && setBound(true, val, false) && setBound(false, val, false)
+	case "gt":
+		return hasNumericParam && setBound(true, val, true)
+	case "gte":
+		return hasNumericParam && setBound(true, val, false)
+	case "lt":
+		return hasNumericParam && setBound(false, val, true)
+	case "lte":
+		return hasNumericParam && setBound(false, val, false)
+	case "eq", "eq_ignore_case":
+		if isCollection || isMap {
+			return hasNumericParam && setBound(true, val, false) && setBound(false, val, false)
+		}
+		if tag == "eq_ignore_case" {
+			addPattern(schema, "^(?i:"+regexp.QuoteMeta(param)+")$")
+			return true
+		}
+		schema.Enum = []any{validationTagValue(param, t)}
+		return true
+	case "ne":
+		if isCollection || isMap {
+			return false
+		}
+		schema.Not = &SchemaRef{Value: &Schema{Enum: []any{validationTagValue(param, t)}}}
+		return true
+	case "minLength":
+		length, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return false
+		}
+		schema.MinLength = length
+		return true
+	case "maxLength":
+		length, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return false
+		}
+		schema.MaxLength = &length
+		return true
+	case "unique", "uniqueItems":
+		schema.UniqueItems = true
+		return true
+	case "startswith":
+		addPattern(schema, "^"+regexp.QuoteMeta(param))
+		return true
+	case "endswith":
+		addPattern(schema, regexp.QuoteMeta(param)+"$")
+		return true
+	case "contains":
+		addPattern(schema, regexp.QuoteMeta(param))
+		return true
+	case "containsany":
+		addPattern(schema, "["+regexp.QuoteMeta(param)+"]")
+		return true
+	case "excludesall":
+		addPattern(schema, "^[^"+regexp.QuoteMeta(param)+"]*$")
+		return true
+	case "excludes", "startsnotwith", "endsnotwith":
+		pattern := regexp.QuoteMeta(param)
+		if tag == "startsnotwith" {
+			pattern = "^" + pattern
+		} else if tag == "endsnotwith" {
+			pattern += "$"
+		}
+		schema.Not = &SchemaRef{Value: &Schema{Pattern: pattern}}
+		return true
+	case "datetime":
+		applyDatetimeLayout(schema, param)
+		return true
+	case "ip", "ip_addr":
+		schema.AnyOf = append(schema.AnyOf, &SchemaRef{Value: &Schema{Format: "ipv4"}}, &SchemaRef{Value: &Schema{Format: "ipv6"}})
+		return true
+	case "latitude", "longitude":
+		bound := 90.0
+		if tag == "longitude" {
+			bound = 180
+		}
+		if isNumber {
+			lower := -bound
+			schema.Min, schema.Max = &lower, &bound
+			return true
+		}
+		return false
+	case "json":
+		schema.ContentMediaType = "application/json"
+		return true
+	}
+	return false
+}
+
+// formats defined by the openapi / json schema specs
+var validationTagFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"uri":              "uri",
+	"http_url":         "uri",
+	"https_url":        "uri",
+	"urn_rfc2141":      "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"uuid3_rfc4122":    "uuid",
+	"uuid4_rfc4122":    "uuid",
+	"uuid5_rfc4122":    "uuid",
+	"ipv4":             "ipv4",
+	"ip4_addr":         "ipv4",
+	"ipv6":             "ipv6",
+	"ip6_addr":         "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+	"base64rawurl":     "byte",
+}
+
+// patterns of the tags without a matching format
+var validationTagPatterns = map[string]string{
+	"alpha":            `^[a-zA-Z]+$`,
+	"alphanum":         `^[a-zA-Z0-9]+$`,
+	"alphaunicode":     `^[\p{L}]+$`,
+	"alphanumunicode":  `^[\p{L}\p{N}]+$`,
+	"numeric":          `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":           `^[0-9]+$`,
+	"hexadecimal":      `^(0[xX])?[0-9a-fA-F]+$`,
+	"hexcolor":         `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`,
+	"rgb":              `^rgb\(\s*(?:(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])\s*,\s*){2}(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])\s*\)$`,
+	"rgba":             `^rgba\(\s*(?:(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])\s*,\s*){3}(?:(?:0\.[0-9]*)|[01])\s*\)$`,
+	"lowercase":        `^[^A-Z]*$`,
+	"uppercase":        `^[^a-z]*$`,
+	"ascii":            `^[\x00-\x7F]*$`,
+	"printascii":       `^[\x20-\x7E]*$`,
+	"boolean":          `^(?:1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)$`,
+	"e164":             `^\+[1-9]?[0-9]{7,14}$`,
+	"jwt":              `^[A-Za-z0-9-_]+\.[A-Za-z0-9-_]+\.[A-Za-z0-9-_]*$`,
+	"semver":           `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`,
+	"mac":              `^(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`,
+	"cidrv4":           `^(?:\d{1,3}\.){3}\d{1,3}/\d{1,2}$`,
+	"ulid":             `^[0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{26}$`,
+	"base64url":        `^[A-Za-z0-9_-]*={0,2}$`,
+	"md5":              `^[0-9a-f]{32}$`,
+	"sha256":           `^[0-9a-f]{64}$`,
+	"iso3166_1_alpha2": `^[A-Z]{2}$`,
+	"iso3166_1_alpha3": `^[A-Z]{3}$`,
+	"iso4217":          `^[A-Z]{3}$`,
+	"btc_addr":         `^[13][a-km-zA-HJ-NP-Z1-9]{25,34}$`,
+	"eth_addr":         `^0x[0-9a-fA-F]{40}$`,
+}
+
+// addPattern sets the pattern, or adds it using allOf when the schema already has one (json schema allows only a single pattern).
+func addPattern(schema *Schema, pattern string) {
+	if schema.Pattern == "" {
+		schema.Pattern = pattern
+		return
+	}
+	schema.AllOf = append(schema.AllOf, &SchemaRef{Value: &Schema{Pattern: pattern}})
+}
+
+// ownedSchema returns a schema which can be modified without touching shared ones.
+// Referenced components get wrapped using allOf, inline schemas get copied.
+func ownedSchema(ref *SchemaRef) *Schema {
+	if ref.Ref != "" {
+		wrapped := &Schema{AllOf: SchemaRefs{&SchemaRef{Ref: ref.Ref, Value: ref.Value}}}
+		ref.Ref, ref.Value = "", wrapped
+		return wrapped
+	}
+	if ref.Value == nil {
+		ref.Value = &Schema{}
+	}
+	owned := *ref.Value
+	ref.Value = &owned
+	return &owned
+}
+
+func mapValueSchema(mapSchema *Schema) *Schema {
+	for _, values := range mapSchema.PatternProperties {
+		return ownedSchema(values)
+	}
+	if mapSchema.AdditionalProperties.Schema == nil {
+		has := true
+		mapSchema.AdditionalProperties = AdditionalProperties{Has: &has, Schema: &SchemaRef{Value: &Schema{}}}
+	}
+	return ownedSchema(mapSchema.AdditionalProperties.Schema)
+}
+
+// splitOneOfValues splits the values of `oneof` the same way validator does, values containing spaces can be wrapped in single quotes.
+func splitOneOfValues(raw string) []string {
+	values := []string{}
+	for _, match := range oneOfValuesRegex.FindAllStringSubmatch(raw, -1) {
+		if match[1] != "" || strings.HasPrefix(match[0], "'") {
+			values = append(values, match[1])
+		} else {
+			values = append(values, match[2])
+		}
+	}
+	return values
+}
+
+var oneOfValuesRegex = regexp.MustCompile(`'([^']*)'|(\S+)`)
+
+// validationTagValue parses a value compared by a validator tag (eg. `oneof`), keeping the unparsable ones as strings,
+// the same way validator compares them.
+func validationTagValue(raw string, t reflect.Type) any {
+	value, _ := parseTagValue(raw, t, nil)
+	return value
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+var crossFieldValidationNotes = map[string]string{
+	"eqfield":       "Must be equal to `%s`.",
+	"eqcsfield":     "Must be equal to `%s`.",
+	"nefield":       "Must not be equal to `%s`.",
+	"necsfield":     "Must not be equal to `%s`.",
+	"gtfield":       "Must be greater than `%s`.",
+	"gtcsfield":     "Must be greater than `%s`.",
+	"gtefield":      "Must be greater than or equal to `%s`.",
+	"gtecsfield":    "Must be greater than or equal to `%s`.",
+	"ltfield":       "Must be less than `%s`.",
+	"ltcsfield":     "Must be less than `%s`.",
+	"ltefield":      "Must be less than or equal to `%s`.",
+	"ltecsfield":    "Must be less than or equal to `%s`.",
+	"fieldcontains": "Must contain the value of `%s`.",
+	"fieldexcludes": "Must not contain the value of `%s`.",
+}
+
+// applyConditionalValidationTag translates the tags which depend on other fields of the struct (`required_if`, `excluded_with`, ...)
+// into if / then / else, dependentRequired or dependentSchemas of the parent. Tags comparing fields (`eqfield`, ...) have
+// no json schema equivalent, so they get described instead. Returns false for all the other tags.
+func applyConditionalValidationTag(parentType reflect.Type, parent *Schema, schema *Schema, fieldName string, tag string, param string) bool {
+	if note, ok := crossFieldValidationNotes[tag]; ok {
+		other := param
+		if !strings.HasSuffix(tag, "csfield") {
+			other, _ = otherFieldName(parentType, param)
+		}
+		schema.Description = strings.TrimSpace(schema.Description + " " + fmt.Sprintf(note, other))
+		return true
+	}
+
+	params := strings.Fields(param)
+	others := []string{}
+	for _, other := range params {
+		name, _ := otherFieldName(parentType, other)
+		others = append(others, name)
+	}
+	consequence := &SchemaRef{Value: &Schema{Required: []string{fieldName}}}
+	if strings.HasPrefix(tag, "excluded_") {
+		consequence = &SchemaRef{Value: &Schema{Not: &SchemaRef{Value: &Schema{Required: []string{fieldName}}}}}
+	}
+	addConditional := func(condition *Schema, negate bool) {
+		conditional := &Schema{If: &SchemaRef{Value: condition}}
+		if negate {
+			conditional.Else = consequence
+		} else {
+			conditional.Then = consequence
+		}
+		parent.AllOf = append(parent.AllOf, &SchemaRef{Value: conditional})
+	}
+
+	switch tag {
+	case "required_if", "excluded_if", "required_unless", "excluded_unless":
+		if len(params) == 0 || len(params)%2 != 0 {
+			return false
+		}
+		condition := &Schema{Properties: Schemas{}}
+		for i := 0; i < len(params); i += 2 {
+			name, otherType := otherFieldName(parentType, params[i])
+			condition.Properties[name] = &SchemaRef{Value: &Schema{Enum: []any{validationTagValue(params[i+1], otherType)}}}
+			condition.Required = append(condition.Required, name)
+		}
+		addConditional(condition, strings.HasSuffix(tag, "_unless"))
+	case "required_with":
+		if parent.DependentRequired == nil {
+			parent.DependentRequired = map[string][]string{}
+		}
+		for _, other := range others {
+			if !slices.Contains(parent.DependentRequired[other], fieldName) {
+				parent.DependentRequired[other] = append(parent.DependentRequired[other], fieldName)
+			}
+		}
+	case "excluded_with":
+		if parent.DependentSchemas == nil {
+			parent.DependentSchemas = Schemas{}
+		}
+		for _, other := range others {
+			if existing := parent.DependentSchemas[other]; existing != nil {
+				existing.Value.AllOf = append(existing.Value.AllOf, consequence)
+			} else {
+				parent.DependentSchemas[other] = &SchemaRef{Value: &Schema{AllOf: SchemaRefs{consequence}}}
+			}
+		}
+	case "required_with_all", "excluded_with_all":
+		addConditional(&Schema{Required: others}, false)
+	case "required_without", "excluded_without":
+		for _, other := range others {
+			addConditional(&Schema{Required: []string{other}}, true)
+		}
+	case "required_without_all", "excluded_without_all":
+		condition := &Schema{}
+		for _, other := range others {
+			condition.AnyOf = append(condition.AnyOf, &SchemaRef{Value: &Schema{Required: []string{other}}})
+		}
+		addConditional(condition, true)
+	default:
+		return false
+	}
+	return true
+}
+
+// otherFieldName returns the property name and the type of the field referenced by a tag using its go name.
+func otherFieldName(parentType reflect.Type, goName string) (string, reflect.Type) {
+	if parentType != nil && parentType.Kind() == reflect.Struct {
+		if field, ok := parentType.FieldByName(goName); ok {
+			return schemaFieldName(field, fieldNameTags(parentType)), derefType(field.Type)
+		}
+	}
+	return goName, reflect.TypeFor[string]()
+}
@@ -0,0 +1,183 @@
+package gofiberswagger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ValidationTags struct {
+	Email      string            `json:"email" validate:"required,email"`
+	Website    string            `json:"website" validate:"url"`
+	Id         string            `json:"id" validate:"uuid4"`
+	Address    string            `json:"address" validate:"ipv4"`
+	AnyIp      string            `json:"any_ip" validate:"ip"`
+	Code       string            `json:"code" validate:"len=6,alphanum"`
+	Age        int               `json:"age" validate:"gt=0,lte=150"`
+	Score      float64           `json:"score" validate:"gte=0.5,lt=10"`
+	Prefixed   string            `json:"prefixed" validate:"startswith=ab.,contains=x"`
+	Phone      string            `json:"phone" validate:"e164"`
+	Color      string            `json:"color" validate:"hexcolor|rgb"`
+	Born       string            `json:"born" validate:"datetime=2006-01-02"`
+	Tags       []string          `json:"tags" validate:"min=1,max=5,unique,dive,min=2,alpha"`
+	Exactly    []int             `json:"exactly" validate:"len=3"`
+	Labels     map[string]string `json:"labels" validate:"gt=0,dive,keys,lowercase,endkeys,required,max=10"`
+	Level      int               `json:"level" validate:"oneof=1 2 3"`
+	Quoted     string            `json:"quoted" validate:"oneof='a b' c"`
+	Not        string            `json:"not" validate:"ne=admin"`
+	Pointer    *string           `json:"pointer" validate:"min=3"`
+	CrossField string            `json:"cross_field" validate:"eqfield=Email"`
+	Nested     []ValidationChild `json:"nested" validate:"dive,required"`
+	Custom     string            `json:"custom" validate:"iso_weekday"`
+}
+
+type ValidationChild struct {
+	Name string `json:"name"`
+}
+
+func TestApplyValidationTags(t *testing.T) {
+	RegisterValidationTag("iso_weekday", func(schema *Schema, param string, t reflect.Type) {
+		schema.Enum = []any{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+	})
+
+	generator := NewGenerator(Config{})
+	schema := generator.CreateSchema(reflect.TypeFor[ValidationTags]())
+	props := schema.Value.Properties
+
+	assert.Equal(t, []string{"email"}, schema.Value.Required)
+	assert.Equal(t, "email", props["email"].Value.Format)
+	assert.Equal(t, "uri", props["website"].Value.Format)
+	assert.Equal(t, "uuid", props["id"].Value.Format)
+	assert.Equal(t, "ipv4", props["address"].Value.Format)
+	assert.Len(t, props["any_ip"].Value.AnyOf, 2)
+
+	code := props["code"].Value
+	assert.Equal(t, uint64(6), code.MinLength)
+	assert.Equal(t, uint64(6), *code.MaxLength)
+	assert.Equal(t, "^[a-zA-Z0-9]+$", code.Pattern)
+
+	age := props["age"].Value
+	assert.Nil(t, age.Min)
+	assert.Equal(t, float64(0), *age.ExclusiveMin.Value)
+	assert.Equal(t, float64(150), *age.Max)
+
+	score := props["score"].Value
+	assert.Equal(t, 0.5, *score.Min)
+	// the bounds don't set a default
+	assert.Nil(t, score.Default)
+	assert.Equal(t, float64(10), *score.ExclusiveMax.Value)
+	assert.Nil(t, score.Max)
+
+	prefixed := props["prefixed"].Value
+	assert.Equal(t, `^ab\.`, prefixed.Pattern)
+	assert.Len(t, prefixed.AllOf, 1)
+	assert.Equal(t, "x", prefixed.AllOf[0].Value.Pattern)
+
+	assert.Equal(t, `^\+[1-9]?[0-9]{7,14}$`, props["phone"].Value.Pattern)
+	assert.Len(t, props["color"].Value.AnyOf, 2)
+	assert.Equal(t, "date", props["born"].Value.Format)
+
+	tags := props["tags"].Value
+	assert.Equal(t, uint64(1), tags.MinItems)
+	assert.Equal(t, uint64(5), *tags.MaxItems)
+	assert.True(t, tags.UniqueItems)
+	assert.Equal(t, uint64(2), tags.Items.Value.MinLength)
+	assert.Equal(t, "^[a-zA-Z]+$", tags.Items.Value.Pattern)
+
+	exactly := props["exactly"].Value
+	assert.Equal(t, uint64(3), exactly.MinItems)
+	assert.Equal(t, uint64(3), *exactly.MaxItems)
+
+	labels := props["labels"].Value
+	assert.Equal(t, uint64(1), labels.MinProps)
+	assert.Equal(t, "^[^A-Z]*$", labels.PropertyNames.Value.Pattern)
+	assert.Equal(t, uint64(10), *labels.AdditionalProperties.Schema.Value.MaxLength)
+
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, props["level"].Value.Enum)
+	assert.Equal(t, []any{"a b", "c"}, props["quoted"].Value.Enum)
+	assert.Equal(t, []any{"admin"}, props["not"].Value.Not.Value.Enum)
+	assert.Equal(t, uint64(3), props["pointer"].Value.MinLength)
+	assert.Empty(t, props["cross_field"].Value.Pattern)
+
+	// the component of the elements stays untouched, it gets wrapped instead
+	nested := props["nested"].Value.Items
+	assert.Empty(t, nested.Ref)
+	assert.Equal(t, "#/components/schemas/"+PackageQualifiedSchemaNames(reflect.TypeFor[ValidationChild]()), nested.Value.AllOf[0].Ref)
+
+	assert.Len(t, props["custom"].Value.Enum, 7)
+}
+
+func TestSplitOneOfValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"a", "b"}, splitOneOfValues("a b"))
+	assert.Equal(t, []string{"a b", "c", ""}, splitOneOfValues("'a b' c ''"))
+}
+
+type ConditionalValidationTags struct {
+	Kind         string `json:"kind"`
+	Count        int    `json:"count"`
+	Company      string `json:"company" validate:"required_if=Kind business Count 2"`
+	Reason       string `json:"reason" validate:"required_unless=Kind personal"`
+	Street       string `json:"street"`
+	City         string `json:"city" validate:"required_with=Street"`
+	Phone        string `json:"phone"`
+	Email        string `json:"email" validate:"required_without=Phone"`
+	Fax          string `json:"fax" validate:"excluded_with=Phone"`
+	Discount     int    `json:"discount" validate:"excluded_unless=Kind business"`
+	Password     string `json:"password"`
+	Confirm      string `json:"confirm" validate:"required,eqfield=Password"`
+	Fallback     string `json:"fallback" validate:"required_without_all=Phone Email"`
+	AllOrNothing string `json:"all_or_nothing" validate:"required_with_all=Street City"`
+}
+
+func TestApplyValidationTags_Conditional(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(Config{})
+	schema := generator.CreateSchema(reflect.TypeFor[ConditionalValidationTags]()).Value
+
+	conditionals := map[string]*Schema{}
+	for _, sub := range schema.AllOf {
+		consequence := sub.Value.Then
+		if consequence == nil {
+			consequence = sub.Value.Else
+		}
+		name := ""
+		if len(consequence.Value.Required) > 0 {
+			name = consequence.Value.Required[0]
+		} else {
+			name = consequence.Value.Not.Value.Required[0]
+		}
+		conditionals[name] = sub.Value
+	}
+
+	company := conditionals["company"]
+	assert.NotNil(t, company)
+	assert.Equal(t, []string{"kind", "count"}, company.If.Value.Required)
+	assert.Equal(t, []any{"business"}, company.If.Value.Properties["kind"].Value.Enum)
+	assert.Equal(t, []any{int64(2)}, company.If.Value.Properties["count"].Value.Enum)
+	assert.NotNil(t, company.Then)
+
+	reason := conditionals["reason"]
+	assert.NotNil(t, reason)
+	assert.Nil(t, reason.Then)
+	assert.Equal(t, []string{"reason"}, reason.Else.Value.Required)
+
+	email := conditionals["email"]
+	assert.Equal(t, []string{"phone"}, email.If.Value.Required)
+	assert.NotNil(t, email.Else)
+
+	discount := conditionals["discount"]
+	assert.Equal(t, []string{"discount"}, discount.Else.Value.Not.Value.Required)
+
+	assert.Len(t, conditionals["fallback"].If.Value.AnyOf, 2)
+	assert.Equal(t, []string{"street", "city"}, conditionals["all_or_nothing"].If.Value.Required)
+
+	assert.Equal(t, map[string][]string{"street": {"city"}}, schema.DependentRequired)
+	assert.Equal(t, []string{"fax"}, schema.DependentSchemas["phone"].Value.AllOf[0].Value.Not.Value.Required)
+
+	assert.Equal(t, "Must be equal to `password`.", schema.Properties["confirm"].Value.Description)
+	assert.Equal(t, []string{"confirm"}, schema.Required)
+}